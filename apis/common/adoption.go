@@ -0,0 +1,22 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package common
+
+// AdoptionPolicy defines whether a component may take over child resources which already exist in
+// the cluster.
+// +kubebuilder:validation:Enum=Never;IfUnowned;Force
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyNever never adopts existing resources; an existing resource which is not
+	// controlled by the component is reported as a conflict.
+	AdoptionPolicyNever AdoptionPolicy = "Never"
+
+	// AdoptionPolicyIfUnowned adopts existing resources which are not controlled by another owner.
+	AdoptionPolicyIfUnowned AdoptionPolicy = "IfUnowned"
+
+	// AdoptionPolicyForce adopts existing resources, replacing the controller reference of any
+	// other owner.
+	AdoptionPolicyForce AdoptionPolicy = "Force"
+)
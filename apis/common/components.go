@@ -14,30 +14,41 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 type Component interface {
+	GetAdoptionPolicy() AdoptionPolicy
 	GetComponentGVK() schema.GroupVersionKind
 	GetDependencies() []Component
 	GetDependencyStatus() bool
+	GetDeletionPolicy() DeletionPolicy
+	GetDriftPolicy() DriftPolicy
 	GetReadyStatus() bool
 	GetPhaseConditions() []PhaseCondition
 	GetResources() []Resource
+	GetStatusConditions() []metav1.Condition
+	IsSuspended() bool
 
 	SetReadyStatus(bool)
 	SetDependencyStatus(bool)
 	SetPhaseCondition(PhaseCondition)
 	SetResource(Resource)
+	SetStatusCondition(metav1.Condition)
+	SetObservedGeneration(int64)
+	RemoveResource(Resource)
 }
 
 type ComponentReconciler interface {
 	// attribute exporters and setters
 	GetClient() client.Client
+	GetAPIReader() client.Reader
 	GetComponent() Component
 	GetContext() context.Context
 	GetController() controller.Controller
+	GetEventRecorder() record.EventRecorder
 	GetLogger() logr.Logger
 	GetScheme() *runtime.Scheme
 	GetResources() []ComponentResource
@@ -54,6 +65,7 @@ type ComponentReconciler interface {
 	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
 	Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error
 	Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
 
 	// custom methods which are managed by consumers
 	CheckReady() (bool, error)
@@ -77,5 +89,6 @@ type ComponentResource interface {
 
 	// other methods
 	IsReady() (bool, error)
+	IsShared() bool
 	ToCommonResource() *Resource
 }
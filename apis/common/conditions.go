@@ -14,6 +14,14 @@ const (
 	PhaseStateComplete    PhaseState = "Complete"
 )
 
+// Below are the types of the standard conditions which summarize the reconciliation of a component.
+const (
+	ConditionTypeReady       = "Ready"
+	ConditionTypeReconciling = "Reconciling"
+	ConditionTypeStalled     = "Stalled"
+	ConditionTypeSuspended   = "Suspended"
+)
+
 // PhaseCondition describes an event that has occurred during a phase
 // of the controller reconciliation loop.
 type PhaseCondition struct {
@@ -42,6 +50,13 @@ type ResourceCondition struct {
 
 	// Message defines a helpful message from the resource phase.
 	Message string `json:"message,omitempty"`
+
+	// Drifted defines whether this resource differs from its desired state because it was modified
+	// or deleted by something other than the controller, and the drift has not been corrected.
+	Drifted bool `json:"drifted,omitempty"`
+
+	// Drift summarizes the most recent drift of this resource from its desired state.
+	Drift string `json:"drift,omitempty"`
 }
 
 // GetPhaseConditionIndex returns the index of a matching phase condition.  Any integer which is 0
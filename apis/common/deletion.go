@@ -0,0 +1,22 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package common
+
+// DeletionPolicy defines what happens to the child resources of a component when the component
+// is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;RetainIfNotEmpty
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the child resources along with the component.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyRetain retains the child resources when the component is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+
+	// DeletionPolicyRetainIfNotEmpty retains the child resources when the component is deleted if
+	// any of the namespaces which are managed by the component contain pods or persistent volume
+	// claims, and deletes them otherwise.
+	DeletionPolicyRetainIfNotEmpty DeletionPolicy = "RetainIfNotEmpty"
+)
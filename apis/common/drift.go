@@ -0,0 +1,17 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package common
+
+// DriftPolicy defines what happens when a child resource of a component has been modified or
+// deleted by something other than the controller.
+// +kubebuilder:validation:Enum=Enforce;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce restores the child resource to its desired state and reports the drift.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+
+	// DriftPolicyReport reports the drift without restoring the child resource.
+	DriftPolicyReport DriftPolicy = "Report"
+)
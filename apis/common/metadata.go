@@ -0,0 +1,16 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package common
+
+const (
+	// ManagedLabelsAnnotation is the annotation which stores the keys of the labels of a child
+	// resource which are managed by its component, separated by commas, so that labels which are
+	// no longer desired may be removed.
+	ManagedLabelsAnnotation = "tenancy.platform.cnr.vmware.com/managed-labels"
+
+	// ManagedAnnotationsAnnotation is the annotation which stores the keys of the annotations of a
+	// child resource which are managed by its component, separated by commas, so that annotations
+	// which are no longer desired may be removed.
+	ManagedAnnotationsAnnotation = "tenancy.platform.cnr.vmware.com/managed-annotations"
+)
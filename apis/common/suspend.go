@@ -0,0 +1,8 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package common
+
+// SuspendAnnotation is the annotation which suspends the reconciliation of a component when it is
+// set to "true", in the same way as the suspend field of the component.
+const SuspendAnnotation = "tenancy.platform.cnr.vmware.com/suspend"
@@ -143,6 +143,34 @@ var rbacDefaults = map[string]map[string]string{
 	},
 }
 
+// DefaultSpec returns a copy of the spec of a TanzuNamespace in which the name and the limitRange
+// and resourceQuota fields are set to the values which are used to create its child resources.
+// Empty fields take the values of their backwards compatibility fields, or the default constants
+// if those are also empty.
+func DefaultSpec(parent *tenancyv1alpha1.TanzuNamespace) tenancyv1alpha1.TanzuNamespaceSpec {
+	spec := *parent.Spec.DeepCopy()
+
+	spec.Name = defaultNamespace(parent.Name, &parent.Spec)
+
+	spec.LimitRange = tenancyv1alpha1.LimitRange{
+		DefaultCPULimit:      defaultLimitRangeDefaultCPULimit(&parent.Spec),
+		DefaultMemoryLimit:   defaultLimitRangeDefaultMemoryLimit(&parent.Spec),
+		DefaultCPURequest:    defaultLimitRangeDefaultCPURequest(&parent.Spec),
+		DefaultMemoryRequest: defaultLimitRangeDefaultMemoryRequest(&parent.Spec),
+		MaxCPULimit:          defaultLimitRangeMaxCPULimit(&parent.Spec),
+		MaxMemoryLimit:       defaultLimitRangeMaxMemoryLimit(&parent.Spec),
+	}
+
+	spec.ResourceQuota = tenancyv1alpha1.ResourceQuota{
+		RequestsCPU:    defaultResourceQuotaCPURequests(&parent.Spec),
+		RequestsMemory: defaultResourceQuotaMemoryRequests(&parent.Spec),
+		LimitsCPU:      defaultResourceQuotaCPULimits(&parent.Spec),
+		LimitsMemory:   defaultResourceQuotaMemoryLimits(&parent.Spec),
+	}
+
+	return spec
+}
+
 func defaultLimitRangeDefaultCPULimit(spec *tenancyv1alpha1.TanzuNamespaceSpec) string {
 	if spec.LimitRange.DefaultCPULimit != "" {
 		return spec.LimitRange.DefaultCPULimit
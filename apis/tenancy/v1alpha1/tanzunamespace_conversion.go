@@ -0,0 +1,431 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+/*
+
+ */
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+const (
+	// V1alpha1SpecAnnotation stores the v1alpha1 spec on a converted v1alpha2 object so that the
+	// fields which may not be represented in v1alpha2 are restored when converting back.
+	V1alpha1SpecAnnotation = "tenancy.platform.cnr.vmware.com/v1alpha1-spec"
+
+	// V1alpha2SpecAnnotation stores the v1alpha2 spec on a converted v1alpha1 object so that the
+	// fields which may not be represented in v1alpha1 are restored when converting back.
+	V1alpha2SpecAnnotation = "tenancy.platform.cnr.vmware.com/v1alpha2-spec"
+
+	// V1alpha2StatusAnnotation stores the status fields which may not be represented in v1alpha1 on
+	// a converted v1alpha1 object so that they are restored when converting back.
+	V1alpha2StatusAnnotation = "tenancy.platform.cnr.vmware.com/v1alpha2-status"
+)
+
+// v1alpha2Status is the part of the v1alpha2 status which may not be represented in v1alpha1.  The
+// phase conditions are stored in full, as the lastModified field of a phase condition may not be
+// represented in v1alpha1.
+type v1alpha2Status struct {
+	Conditions         []common.PhaseCondition                      `json:"conditions,omitempty"`
+	StatusConditions   []metav1.Condition                           `json:"statusConditions,omitempty"`
+	ObservedGeneration int64                                        `json:"observedGeneration,omitempty"`
+	ResourceQuotas     []v1alpha2.TanzuNamespaceStatusResourceQuota `json:"resourceQuotas,omitempty"`
+	QuotaUsage         *v1alpha2.TanzuNamespaceStatusQuotaUsage     `json:"quotaUsage,omitempty"`
+}
+
+// rbacRoles maps the v1alpha1 rbac types to the built-in v1alpha2 roles.  The slice keeps the
+// order of the rbac types stable between conversions.
+var rbacRoles = []struct {
+	rbacType    string
+	role        v1alpha2.TanzuNamespaceRole
+	defaultUser string
+}{
+	{rbacType: "namespace-admin", role: v1alpha2.TanzuNamespaceRoleAdmin, defaultUser: "tanzu-namespace-admin"},
+	{rbacType: "developer", role: v1alpha2.TanzuNamespaceRoleDeveloper, defaultUser: "tanzu-developer"},
+	{rbacType: "read-only", role: v1alpha2.TanzuNamespaceRoleViewer, defaultUser: "tanzu-read-only"},
+}
+
+var _ conversion.Convertible = &TanzuNamespace{}
+
+// ConvertTo converts a v1alpha1 TanzuNamespace to the v1alpha2 hub version.
+func (src *TanzuNamespace) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.TanzuNamespace)
+	if !ok {
+		return fmt.Errorf("expected conversion hub of kind v1alpha2 TanzuNamespace; found %T", dstRaw)
+	}
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	restored := popAnnotation(dst.Annotations, V1alpha2SpecAnnotation)
+	restoredStatus := popAnnotation(dst.Annotations, V1alpha2StatusAnnotation)
+
+	dst.Spec = convertSpecTo(src.Name, &src.Spec)
+
+	// restore the v1alpha2 spec if the v1alpha1 representation of it has not been modified
+	if restored != "" {
+		var restoredSpec v1alpha2.TanzuNamespaceSpec
+		if err := json.Unmarshal([]byte(restored), &restoredSpec); err != nil {
+			return fmt.Errorf("unable to restore v1alpha2 spec from annotation %s; %w", V1alpha2SpecAnnotation, err)
+		}
+
+		if equality.Semantic.DeepEqual(convertSpecFrom(&restoredSpec), src.Spec) {
+			dst.Spec = restoredSpec
+		}
+	}
+
+	if err := setAnnotation(&dst.ObjectMeta.Annotations, V1alpha1SpecAnnotation, src.Spec); err != nil {
+		return err
+	}
+
+	dst.Status.Created = src.Status.Created
+	dst.Status.DependenciesSatisfied = src.Status.DependenciesSatisfied
+	dst.Status.Resources = src.Status.Resources
+	dst.Status.Conditions = nil
+
+	for _, condition := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, common.PhaseCondition{
+			Phase:   condition.Type,
+			State:   common.PhaseState(condition.Status),
+			Message: condition.Message,
+		})
+	}
+
+	// restore the v1alpha2 status fields, and the phase conditions if the v1alpha1 representation
+	// of them has not been modified
+	if restoredStatus != "" {
+		var status v1alpha2Status
+		if err := json.Unmarshal([]byte(restoredStatus), &status); err != nil {
+			return fmt.Errorf("unable to restore v1alpha2 status from annotation %s; %w", V1alpha2StatusAnnotation, err)
+		}
+
+		dst.Status.StatusConditions = status.StatusConditions
+		dst.Status.ObservedGeneration = status.ObservedGeneration
+		dst.Status.ResourceQuotas = status.ResourceQuotas
+		dst.Status.QuotaUsage = status.QuotaUsage
+
+		if equality.Semantic.DeepEqual(convertConditionsFrom(status.Conditions), src.Status.Conditions) {
+			dst.Status.Conditions = status.Conditions
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the v1alpha2 hub version of a TanzuNamespace to a v1alpha1 TanzuNamespace.
+func (dst *TanzuNamespace) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.TanzuNamespace)
+	if !ok {
+		return fmt.Errorf("expected conversion hub of kind v1alpha2 TanzuNamespace; found %T", srcRaw)
+	}
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	restored := popAnnotation(dst.Annotations, V1alpha1SpecAnnotation)
+
+	dst.Spec = convertSpecFrom(&src.Spec)
+
+	// restore the v1alpha1 spec, including the backwards compatibility fields, if the v1alpha2
+	// representation of it has not been modified
+	if restored != "" {
+		var restoredSpec TanzuNamespaceSpec
+		if err := json.Unmarshal([]byte(restored), &restoredSpec); err != nil {
+			return fmt.Errorf("unable to restore v1alpha1 spec from annotation %s; %w", V1alpha1SpecAnnotation, err)
+		}
+
+		if equality.Semantic.DeepEqual(convertSpecTo(src.Name, &restoredSpec), src.Spec) {
+			dst.Spec = restoredSpec
+		}
+	}
+
+	if err := setAnnotation(&dst.ObjectMeta.Annotations, V1alpha2SpecAnnotation, src.Spec); err != nil {
+		return err
+	}
+
+	dst.Status.Created = src.Status.Created
+	dst.Status.DependenciesSatisfied = src.Status.DependenciesSatisfied
+	dst.Status.Resources = src.Status.Resources
+	dst.Status.Conditions = convertConditionsFrom(src.Status.Conditions)
+
+	delete(dst.Annotations, V1alpha2StatusAnnotation)
+
+	status := v1alpha2Status{
+		Conditions:         src.Status.Conditions,
+		StatusConditions:   src.Status.StatusConditions,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		ResourceQuotas:     src.Status.ResourceQuotas,
+		QuotaUsage:         src.Status.QuotaUsage,
+	}
+
+	if !equality.Semantic.DeepEqual(status, v1alpha2Status{}) {
+		if err := setAnnotation(&dst.ObjectMeta.Annotations, V1alpha2StatusAnnotation, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertConditionsFrom converts the v1alpha2 phase conditions to v1alpha1 conditions.
+func convertConditionsFrom(src []common.PhaseCondition) []Condition {
+	var dst []Condition
+
+	for _, condition := range src {
+		dst = append(dst, Condition{
+			Type:    condition.Phase,
+			Status:  string(condition.State),
+			Message: condition.Message,
+		})
+	}
+
+	return dst
+}
+
+// convertSpecTo converts a v1alpha1 spec to a v1alpha2 spec.  The values of the limitRange and
+// resourceQuota fields take precedence over the backwards compatibility fields, as they do when
+// v1alpha1 child resources are created.
+func convertSpecTo(parentName string, src *TanzuNamespaceSpec) v1alpha2.TanzuNamespaceSpec {
+	dst := v1alpha2.TanzuNamespaceSpec{
+		Namespace: firstOf(src.Name, src.TanzuNamespaceName, parentName),
+		Resources: v1alpha2.TanzuNamespaceSpecResources{
+			Limits: v1alpha2.TanzuNamespaceSpecResourcesLimits{
+				Cpu:    firstOf(src.LimitRange.DefaultCPULimit, src.TanzuLimitRangeDefaultCpuLimit),
+				Memory: firstOf(src.LimitRange.DefaultMemoryLimit, src.TanzuLimitRangeDefaultMemoryLimit),
+			},
+			Requests: v1alpha2.TanzuNamespaceSpecResourcesRequests{
+				Cpu:    firstOf(src.LimitRange.DefaultCPURequest, src.TanzuLimitRangeDefaultCpuRequest),
+				Memory: firstOf(src.LimitRange.DefaultMemoryRequest, src.TanzuLimitRangeDefaultMemoryRequest),
+			},
+			Max: v1alpha2.TanzuNamespaceSpecResourcesMax{
+				Cpu:    firstOf(src.LimitRange.MaxCPULimit, src.TanzuLimitRangeMaxCpuLimit),
+				Memory: firstOf(src.LimitRange.MaxMemoryLimit, src.TanzuLimitRangeMaxMemoryLimit),
+			},
+			Quota: v1alpha2.TanzuNamespaceSpecResourcesQuota{
+				Requests: v1alpha2.TanzuNamespaceSpecResourcesQuotaRequests{
+					Cpu:    firstOf(src.ResourceQuota.RequestsCPU, src.TanzuResourceQuotaCpuRequests),
+					Memory: firstOf(src.ResourceQuota.RequestsMemory, src.TanzuResourceQuotaMemoryRequests),
+				},
+				Limits: v1alpha2.TanzuNamespaceSpecResourcesQuotaLimits{
+					Cpu:    firstOf(src.ResourceQuota.LimitsCPU, src.TanzuResourceQuotaCpuLimits),
+					Memory: firstOf(src.ResourceQuota.LimitsMemory, src.TanzuResourceQuotaMemoryLimits),
+				},
+			},
+		},
+	}
+
+	// network policies are named by their index, as they are in v1alpha1
+	for i, networkPolicy := range src.NetworkPolicies {
+		dst.NetworkPolicies = append(dst.NetworkPolicies, v1alpha2.TanzuNamespaceSpecNetworkPolicy{
+			Name:            strconv.Itoa(i),
+			TargetPodLabels: networkPolicy.TargetPodLabels,
+			Ingress: []v1alpha2.TanzuNamespaceSpecNetworkPolicyRule{
+				convertNetworkPolicyRuleTo(
+					networkPolicy.IngressNamespaceLabels,
+					networkPolicy.IngressPodLabels,
+					networkPolicy.IngressTCPPorts,
+					networkPolicy.IngressUDPPorts,
+				),
+			},
+			Egress: []v1alpha2.TanzuNamespaceSpecNetworkPolicyRule{
+				convertNetworkPolicyRuleTo(
+					networkPolicy.EgressNamespaceLabels,
+					networkPolicy.EgressPodLabels,
+					networkPolicy.EgressTCPPorts,
+					networkPolicy.EgressUDPPorts,
+				),
+			},
+		})
+	}
+
+	// only the first rbac entry of each type which is marked for creation is used, as in v1alpha1
+	for _, rbacRole := range rbacRoles {
+		for _, rbac := range src.RBAC {
+			if rbac.Type != rbacRole.rbacType || !rbac.Create {
+				continue
+			}
+
+			dst.RBAC.Bindings = append(dst.RBAC.Bindings, v1alpha2.TanzuNamespaceSpecRBACBinding{
+				Name: rbacRole.rbacType,
+				Role: rbacRole.role,
+				ServiceAccounts: []v1alpha2.TanzuNamespaceSpecRBACServiceAccount{
+					{Name: firstOf(rbac.User, rbacRole.defaultUser), Create: true},
+				},
+			})
+
+			break
+		}
+	}
+
+	return dst
+}
+
+// convertNetworkPolicyRuleTo converts the v1alpha1 fields of an ingress or egress rule to a
+// v1alpha2 network policy rule.  When labels are specified, v1alpha1 allows traffic from or to the
+// namespaces matching the namespace labels and the pods matching the pod labels, where empty
+// labels match all namespaces and all pods within the namespace respectively.
+func convertNetworkPolicyRuleTo(
+	namespaceLabels, podLabels map[string]string,
+	tcpPorts, udpPorts []int,
+) v1alpha2.TanzuNamespaceSpecNetworkPolicyRule {
+	var rule v1alpha2.TanzuNamespaceSpecNetworkPolicyRule
+
+	if len(namespaceLabels) > 0 || len(podLabels) > 0 {
+		namespacePeer := v1alpha2.TanzuNamespaceSpecNetworkPolicyPeer{NamespaceLabels: map[string]string{}}
+		for key, value := range namespaceLabels {
+			namespacePeer.NamespaceLabels[key] = value
+		}
+
+		rule.Peers = append(rule.Peers, namespacePeer)
+
+		if len(podLabels) > 0 {
+			rule.Peers = append(rule.Peers, v1alpha2.TanzuNamespaceSpecNetworkPolicyPeer{PodLabels: podLabels})
+		} else {
+			rule.SameNamespace = true
+		}
+	}
+
+	for _, port := range tcpPorts {
+		rule.Ports = append(rule.Ports, v1alpha2.TanzuNamespaceSpecNetworkPolicyPort{Protocol: "TCP", Port: int32(port)})
+	}
+
+	for _, port := range udpPorts {
+		rule.Ports = append(rule.Ports, v1alpha2.TanzuNamespaceSpecNetworkPolicyPort{Protocol: "UDP", Port: int32(port)})
+	}
+
+	return rule
+}
+
+// convertSpecFrom converts a v1alpha2 spec to a v1alpha1 spec.  Fields which may not be
+// represented in v1alpha1, such as cidr peers and image pull secrets, are dropped.
+func convertSpecFrom(src *v1alpha2.TanzuNamespaceSpec) TanzuNamespaceSpec {
+	dst := TanzuNamespaceSpec{
+		Name: src.Namespace,
+		LimitRange: LimitRange{
+			DefaultCPULimit:      src.Resources.Limits.Cpu,
+			DefaultMemoryLimit:   src.Resources.Limits.Memory,
+			DefaultCPURequest:    src.Resources.Requests.Cpu,
+			DefaultMemoryRequest: src.Resources.Requests.Memory,
+			MaxCPULimit:          src.Resources.Max.Cpu,
+			MaxMemoryLimit:       src.Resources.Max.Memory,
+		},
+		ResourceQuota: ResourceQuota{
+			RequestsCPU:    src.Resources.Quota.Requests.Cpu,
+			RequestsMemory: src.Resources.Quota.Requests.Memory,
+			LimitsCPU:      src.Resources.Quota.Limits.Cpu,
+			LimitsMemory:   src.Resources.Quota.Limits.Memory,
+		},
+	}
+
+	for _, networkPolicy := range src.NetworkPolicies {
+		dstNetworkPolicy := NetworkPolicy{TargetPodLabels: networkPolicy.TargetPodLabels}
+
+		dstNetworkPolicy.IngressNamespaceLabels, dstNetworkPolicy.IngressPodLabels,
+			dstNetworkPolicy.IngressTCPPorts, dstNetworkPolicy.IngressUDPPorts = convertNetworkPolicyRulesFrom(networkPolicy.Ingress)
+
+		dstNetworkPolicy.EgressNamespaceLabels, dstNetworkPolicy.EgressPodLabels,
+			dstNetworkPolicy.EgressTCPPorts, dstNetworkPolicy.EgressUDPPorts = convertNetworkPolicyRulesFrom(networkPolicy.Egress)
+
+		dst.NetworkPolicies = append(dst.NetworkPolicies, dstNetworkPolicy)
+	}
+
+	for _, binding := range src.RBAC.Bindings {
+		for _, rbacRole := range rbacRoles {
+			if binding.Role != rbacRole.role || len(binding.ServiceAccounts) == 0 {
+				continue
+			}
+
+			dst.RBAC = append(dst.RBAC, RBAC{
+				Create:      true,
+				Type:        rbacRole.rbacType,
+				User:        binding.ServiceAccounts[0].Name,
+				Role:        fmt.Sprintf("tanzu-%s-role", binding.Role),
+				RoleBinding: fmt.Sprintf("tanzu-rolebinding-%s", binding.Name),
+			})
+		}
+	}
+
+	return dst
+}
+
+// convertNetworkPolicyRulesFrom converts v1alpha2 network policy rules to the v1alpha1 fields of
+// an ingress or egress rule.  As v1alpha1 only supports a single rule, the labels and ports of
+// each of the rules are combined.
+func convertNetworkPolicyRulesFrom(
+	rules []v1alpha2.TanzuNamespaceSpecNetworkPolicyRule,
+) (namespaceLabels, podLabels map[string]string, tcpPorts, udpPorts []int) {
+	for _, rule := range rules {
+		for _, peer := range rule.Peers {
+			for key, value := range peer.NamespaceLabels {
+				if namespaceLabels == nil {
+					namespaceLabels = map[string]string{}
+				}
+
+				namespaceLabels[key] = value
+			}
+
+			for key, value := range peer.PodLabels {
+				if podLabels == nil {
+					podLabels = map[string]string{}
+				}
+
+				podLabels[key] = value
+			}
+		}
+
+		for _, port := range rule.Ports {
+			switch port.Protocol {
+			case "UDP":
+				udpPorts = append(udpPorts, int(port.Port))
+			case "", "TCP":
+				tcpPorts = append(tcpPorts, int(port.Port))
+			}
+		}
+	}
+
+	return namespaceLabels, podLabels, tcpPorts, udpPorts
+}
+
+// firstOf returns the first of the values which is not empty.
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// popAnnotation removes an annotation from a set of annotations and returns its value.
+func popAnnotation(annotations map[string]string, key string) string {
+	value := annotations[key]
+	delete(annotations, key)
+
+	return value
+}
+
+// setAnnotation stores the JSON representation of a spec or status in an annotation.
+func setAnnotation(annotations *map[string]string, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to store value in annotation %s; %w", key, err)
+	}
+
+	if *annotations == nil {
+		*annotations = map[string]string{}
+	}
+
+	(*annotations)[key] = string(data)
+
+	return nil
+}
@@ -0,0 +1,306 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+// testResources are the resources which are reported in the status of a TanzuNamespace.
+var testResources = []common.Resource{
+	{
+		ResourceCommon: common.ResourceCommon{
+			Version:   "v1",
+			Kind:      "ResourceQuota",
+			Name:      "tanzu-quota",
+			Namespace: "tenant",
+		},
+		ResourceCondition: common.ResourceCondition{
+			Created:           true,
+			LastResourcePhase: "CreateResources",
+		},
+	},
+}
+
+func TestTanzuNamespace_ConvertTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		src    *TanzuNamespace
+		mutate func(hub *v1alpha2.TanzuNamespace)
+	}{
+		{
+			name: "backwards compatibility fields",
+			src: &TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant"},
+				Spec: TanzuNamespaceSpec{
+					TanzuNamespaceName:                  "tenant",
+					TanzuLimitRangeDefaultCpuLimit:      "125m",
+					TanzuLimitRangeDefaultMemoryLimit:   "64Mi",
+					TanzuLimitRangeDefaultCpuRequest:    "125m",
+					TanzuLimitRangeDefaultMemoryRequest: "64Mi",
+					TanzuLimitRangeMaxCpuLimit:          "1000m",
+					TanzuLimitRangeMaxMemoryLimit:       "2Gi",
+					TanzuResourceQuotaCpuRequests:       "2000m",
+					TanzuResourceQuotaMemoryRequests:    "4Gi",
+					TanzuResourceQuotaCpuLimits:         "2000m",
+					TanzuResourceQuotaMemoryLimits:      "4Gi",
+					LimitRange:                          LimitRange{DefaultCPULimit: "250m"},
+				},
+			},
+		},
+		{
+			name: "network policies, rbac and status",
+			src: &TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant", Annotations: map[string]string{"team": "platform"}},
+				Spec: TanzuNamespaceSpec{
+					Name: "tenant",
+					NetworkPolicies: []NetworkPolicy{
+						{
+							TargetPodLabels:       map[string]string{"app": "web"},
+							IngressPodLabels:      map[string]string{"app": "proxy"},
+							IngressTCPPorts:       []int{8080},
+							EgressNamespaceLabels: map[string]string{"team": "data"},
+							EgressUDPPorts:        []int{53},
+						},
+					},
+					RBAC: []RBAC{
+						{
+							Create:      true,
+							Type:        "developer",
+							User:        "deployer",
+							Role:        "tanzu-developer-role",
+							RoleBinding: "tanzu-rolebinding-developer",
+						},
+					},
+				},
+				Status: TanzuNamespaceStatus{
+					Created:               true,
+					DependenciesSatisfied: true,
+					Resources:             testResources,
+					Conditions: []Condition{
+						{Type: "CreateResources", Status: "Complete", Message: "created resources"},
+					},
+				},
+			},
+		},
+		{
+			name: "modified v1alpha2 spec is not restored",
+			src: &TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant"},
+				Spec: TanzuNamespaceSpec{
+					Name:       "tenant",
+					LimitRange: LimitRange{DefaultCPULimit: "125m"},
+				},
+			},
+			mutate: func(hub *v1alpha2.TanzuNamespace) {
+				hub.Spec.Resources.Limits.Cpu = "250m"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hub := &v1alpha2.TanzuNamespace{}
+			if err := tt.src.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+
+			if hub.Annotations[V1alpha1SpecAnnotation] == "" {
+				t.Errorf("ConvertTo() did not set annotation %s", V1alpha1SpecAnnotation)
+			}
+
+			if hub.Status.DependenciesSatisfied != tt.src.Status.DependenciesSatisfied ||
+				!reflect.DeepEqual(hub.Status.Resources, tt.src.Status.Resources) {
+				t.Errorf("ConvertTo() status = %+v, want status %+v", hub.Status, tt.src.Status)
+			}
+
+			want := tt.src.DeepCopy()
+			if tt.mutate != nil {
+				tt.mutate(hub)
+				want.Spec = convertSpecFrom(&hub.Spec)
+			}
+
+			got := &TanzuNamespace{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Spec, want.Spec) {
+				t.Errorf("ConvertFrom() spec = %+v, want %+v", got.Spec, want.Spec)
+			}
+
+			if !reflect.DeepEqual(got.Status, want.Status) {
+				t.Errorf("ConvertFrom() status = %+v, want %+v", got.Status, want.Status)
+			}
+
+			if _, found := got.Annotations[V1alpha1SpecAnnotation]; found {
+				t.Errorf("ConvertFrom() kept annotation %s", V1alpha1SpecAnnotation)
+			}
+
+			for key, value := range tt.src.Annotations {
+				if got.Annotations[key] != value {
+					t.Errorf("ConvertFrom() annotation %s = %q, want %q", key, got.Annotations[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestTanzuNamespace_ConvertFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		src    *v1alpha2.TanzuNamespace
+		mutate func(spoke *TanzuNamespace)
+	}{
+		{
+			name: "fields which may not be represented in v1alpha1",
+			src: &v1alpha2.TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant"},
+				Spec: v1alpha2.TanzuNamespaceSpec{
+					Namespace: "tenant",
+					NetworkPolicies: []v1alpha2.TanzuNamespaceSpecNetworkPolicy{
+						{
+							Name: "allow-cidr",
+							Egress: []v1alpha2.TanzuNamespaceSpecNetworkPolicyRule{
+								{
+									Peers: []v1alpha2.TanzuNamespaceSpecNetworkPolicyPeer{
+										{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+									},
+								},
+							},
+						},
+					},
+					RBAC: v1alpha2.TanzuNamespaceSpecRBAC{
+						Bindings: []v1alpha2.TanzuNamespaceSpecRBACBinding{
+							{Name: "viewers", ClusterRole: "view", Groups: []string{"auditors"}},
+						},
+					},
+					ImagePullSecrets: []v1alpha2.TanzuNamespaceSpecImagePullSecret{
+						{Name: "registry"},
+					},
+				},
+				Status: v1alpha2.TanzuNamespaceStatus{
+					Created:               true,
+					DependenciesSatisfied: true,
+					Resources:             testResources,
+					Conditions: []common.PhaseCondition{
+						{Phase: "CreateResources", State: "Complete", Message: "created resources"},
+					},
+				},
+			},
+		},
+		{
+			name: "status fields which may not be represented in v1alpha1",
+			src: &v1alpha2.TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant", Generation: 2},
+				Spec:       v1alpha2.TanzuNamespaceSpec{Namespace: "tenant"},
+				Status: v1alpha2.TanzuNamespaceStatus{
+					Created:   true,
+					Resources: testResources,
+					Conditions: []common.PhaseCondition{
+						{
+							Phase:        "CreateResources",
+							State:        "Complete",
+							Message:      "created resources",
+							LastModified: "2021-08-01 00:00:00 +0000 UTC",
+						},
+					},
+					StatusConditions: []metav1.Condition{
+						{
+							Type:               common.ConditionTypeReady,
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 2,
+							LastTransitionTime: metav1.NewTime(time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC)),
+							Reason:             "Complete",
+							Message:            "reconciliation is complete",
+						},
+					},
+					ObservedGeneration: 2,
+					ResourceQuotas: []v1alpha2.TanzuNamespaceStatusResourceQuota{
+						{
+							Name:      "tanzu-resource-quota",
+							Hard:      corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+							Used:      corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+							Exhausted: []string{"pods"},
+						},
+					},
+					QuotaUsage: &v1alpha2.TanzuNamespaceStatusQuotaUsage{CPU: "45%", Memory: "10%"},
+				},
+			},
+		},
+		{
+			name: "modified v1alpha1 spec is not restored",
+			src: &v1alpha2.TanzuNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant"},
+				Spec: v1alpha2.TanzuNamespaceSpec{
+					Namespace: "tenant",
+					ImagePullSecrets: []v1alpha2.TanzuNamespaceSpecImagePullSecret{
+						{Name: "registry"},
+					},
+				},
+			},
+			mutate: func(spoke *TanzuNamespace) {
+				spoke.Spec.LimitRange.DefaultCPULimit = "250m"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spoke := &TanzuNamespace{}
+			if err := spoke.ConvertFrom(tt.src.DeepCopy()); err != nil {
+				t.Fatalf("ConvertFrom() error = %v", err)
+			}
+
+			if spoke.Annotations[V1alpha2SpecAnnotation] == "" {
+				t.Errorf("ConvertFrom() did not set annotation %s", V1alpha2SpecAnnotation)
+			}
+
+			want := tt.src.DeepCopy()
+			if tt.mutate != nil {
+				tt.mutate(spoke)
+				want.Spec = convertSpecTo(spoke.Name, &spoke.Spec)
+			}
+
+			got := &v1alpha2.TanzuNamespace{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Spec, want.Spec) {
+				t.Errorf("ConvertTo() spec = %+v, want %+v", got.Spec, want.Spec)
+			}
+
+			if !equality.Semantic.DeepEqual(got.Status, want.Status) {
+				t.Errorf("ConvertTo() status = %+v, want %+v", got.Status, want.Status)
+			}
+
+			for _, annotation := range []string{V1alpha2SpecAnnotation, V1alpha2StatusAnnotation} {
+				if _, found := got.Annotations[annotation]; found {
+					t.Errorf("ConvertTo() kept annotation %s", annotation)
+				}
+			}
+		})
+	}
+}
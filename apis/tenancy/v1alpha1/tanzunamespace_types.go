@@ -7,6 +7,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -157,6 +159,11 @@ type TanzuNamespaceStatus struct {
 
 	Created    bool        `json:"created,omitempty"`
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// DependenciesSatisfied and Resources mirror the fields of the v1alpha2 status, so that they
+	// are preserved when the status is converted to and from v1alpha2.
+	DependenciesSatisfied bool              `json:"dependenciesSatisfied,omitempty"`
+	Resources             []common.Resource `json:"resources,omitempty"`
 }
 
 // Condition sets the status.conditions field on the object
@@ -9,6 +9,7 @@
 package v1alpha1
 
 import (
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -253,6 +254,11 @@ func (in *TanzuNamespaceStatus) DeepCopyInto(out *TanzuNamespaceStatus) {
 		*out = make([]Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]common.Resource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceStatus.
@@ -0,0 +1,107 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tanzunamespace
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+const (
+	// ImagePullSecretSourceAnnotation is the annotation which stores the name of the source secret
+	// which an image pull secret is copied from.
+	ImagePullSecretSourceAnnotation = "tenancy.platform.cnr.vmware.com/image-pull-secret-source"
+
+	// ImagePullSecretsAnnotation is the annotation which stores the names of the image pull secrets
+	// which are managed on the default ServiceAccount, separated by commas.
+	ImagePullSecretsAnnotation = "tenancy.platform.cnr.vmware.com/image-pull-secrets"
+
+	// ImagePullSecretKey is the key of the secret data which stores the docker configuration.
+	ImagePullSecretKey = ".dockerconfigjson"
+
+	// DefaultServiceAccountName is the name of the ServiceAccount which is used by pods within the
+	// namespace unless otherwise specified.
+	DefaultServiceAccountName = "default"
+)
+
+// CreateImagePullSecretsTanzuImagePullSecrets creates a Secret resource for each of the entries in
+// the imagePullSecrets field.  The data of the secret is copied from the source secret during the
+// mutate phase.
+func CreateImagePullSecretsTanzuImagePullSecrets(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	for _, imagePullSecret := range parent.Spec.ImagePullSecrets {
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"name":      imagePullSecret.Name,
+					"namespace": parent.Spec.Namespace,
+					"annotations": map[string]interface{}{
+						ImagePullSecretSourceAnnotation: imagePullSecret.GetSourceSecret(),
+					},
+				},
+				"type": "kubernetes.io/dockerconfigjson",
+			},
+		}
+
+		resourceObjs = append(resourceObjs, resourceObj)
+	}
+
+	return resourceObjs, nil
+}
+
+// CreateServiceAccountTanzuImagePullSecrets creates the default ServiceAccount resource, which
+// references each of the entries in the imagePullSecrets field.  The default ServiceAccount is
+// created by Kubernetes and is therefore a shared resource, which is patched rather than owned.  It
+// is returned even when there are no entries, so that the image pull secrets which were previously
+// managed are removed from it; the image pull secrets which are not managed by the operator are
+// merged in, and the resource is skipped when it has nothing to remove, during the mutate phase.
+func CreateServiceAccountTanzuImagePullSecrets(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	imagePullSecrets := []interface{}{}
+	names := []string{}
+
+	for _, imagePullSecret := range parent.Spec.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, map[string]interface{}{
+			"name": imagePullSecret.Name,
+		})
+
+		names = append(names, imagePullSecret.Name)
+	}
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ServiceAccount",
+			"metadata": map[string]interface{}{
+				"name":      DefaultServiceAccountName,
+				"namespace": parent.Spec.Namespace,
+				"annotations": map[string]interface{}{
+					ImagePullSecretsAnnotation: strings.Join(names, ","),
+				},
+			},
+			"imagePullSecrets": imagePullSecrets,
+		},
+	}
+
+	return []metav1.Object{resourceObj}, nil
+}
+
+// IsSharedResource returns whether a resource exists independently of the parent, in which case it
+// is patched without a controller reference and is never pruned or deleted along with the parent.
+func IsSharedResource(object metav1.Object) bool {
+	resourceObj, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+
+	return resourceObj.GetKind() == "ServiceAccount" && resourceObj.GetName() == DefaultServiceAccountName
+}
@@ -10,9 +10,70 @@ import (
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
 )
 
-// CreateLimitRangeTanzuLimitRange creates the tanzu-limit-range LimitRange resource.
+// CreateLimitRangeTanzuLimitRange creates the tanzu-limit-range LimitRange resource.  Fields of the
+// spec which are empty are omitted from the LimitRange, as are the limit types which have no
+// constraints.
 func CreateLimitRangeTanzuLimitRange(
 	parent *tenancyv1alpha2.TanzuNamespace) (metav1.Object, error) {
+	specResources := parent.Spec.Resources
+
+	limits := []interface{}{}
+
+	// controlled by resources.limits, resources.requests, resources.max, resources.min and
+	// resources.maxLimitRequestRatio
+	if container := limitRangeItem("Container", map[string]map[string]string{
+		"default": {
+			"cpu":               specResources.Limits.Cpu,
+			"memory":            specResources.Limits.Memory,
+			"ephemeral-storage": specResources.Limits.EphemeralStorage,
+		},
+		"defaultRequest": {
+			"cpu":               specResources.Requests.Cpu,
+			"memory":            specResources.Requests.Memory,
+			"ephemeral-storage": specResources.Requests.EphemeralStorage,
+		},
+		"max": {
+			"cpu":               specResources.Max.Cpu,
+			"memory":            specResources.Max.Memory,
+			"ephemeral-storage": specResources.Max.EphemeralStorage,
+		},
+		"min": {
+			"cpu":               specResources.Min.Cpu,
+			"memory":            specResources.Min.Memory,
+			"ephemeral-storage": specResources.Min.EphemeralStorage,
+		},
+		"maxLimitRequestRatio": {
+			"cpu":    specResources.MaxLimitRequestRatio.Cpu,
+			"memory": specResources.MaxLimitRequestRatio.Memory,
+		},
+	}); container != nil {
+		limits = append(limits, container)
+	}
+
+	// controlled by resources.pod
+	if pod := limitRangeItem("Pod", map[string]map[string]string{
+		"max": {
+			"cpu":               specResources.Pod.Max.Cpu,
+			"memory":            specResources.Pod.Max.Memory,
+			"ephemeral-storage": specResources.Pod.Max.EphemeralStorage,
+		},
+		"min": {
+			"cpu":               specResources.Pod.Min.Cpu,
+			"memory":            specResources.Pod.Min.Memory,
+			"ephemeral-storage": specResources.Pod.Min.EphemeralStorage,
+		},
+	}); pod != nil {
+		limits = append(limits, pod)
+	}
+
+	// controlled by resources.persistentVolumeClaim
+	if persistentVolumeClaim := limitRangeItem("PersistentVolumeClaim", map[string]map[string]string{
+		"max": {"storage": specResources.PersistentVolumeClaim.Max},
+		"min": {"storage": specResources.PersistentVolumeClaim.Min},
+	}); persistentVolumeClaim != nil {
+		limits = append(limits, persistentVolumeClaim)
+	}
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "v1",
@@ -22,36 +83,39 @@ func CreateLimitRangeTanzuLimitRange(
 				"namespace": parent.Spec.Namespace,
 			},
 			"spec": map[string]interface{}{
-				"limits": []interface{}{
-					map[string]interface{}{
-						"default": map[string]interface{}{
-							// Default CPU limits to be applied to applications which get deployed into this namespace,
-							// but are missing a resources declaration., controlled by resources.limits.cpu
-							"cpu": parent.Spec.Resources.Limits.Cpu,
-							// Default Memory limits to be applied to applications which get deployed into this namespace,
-							// but are missing a resources declaration., controlled by resources.limits.memory
-							"memory": parent.Spec.Resources.Limits.Memory,
-						},
-						"defaultRequest": map[string]interface{}{
-							// Default CPU requests to be applied to applications which get deployed into this namespace,
-							// but are missing a resources declaration., controlled by resources.requests.cpu
-							"cpu": parent.Spec.Resources.Requests.Cpu,
-							// Default Memory requests to be applied to applications which get deployed into this namespace,
-							// but are missing a resources declaration., controlled by resources.requests.memory
-							"memory": parent.Spec.Resources.Requests.Memory,
-						},
-						"max": map[string]interface{}{
-							// Default maximum CPU limits for an individual application which get deployed into this namespace., controlled by resources.max.cpu
-							"cpu": parent.Spec.Resources.Max.Cpu,
-							// Default maximum Memory limits for an individual application which get deployed into this namespace., controlled by resources.max.memory
-							"memory": parent.Spec.Resources.Max.Memory,
-						},
-						"type": "Container",
-					},
-				},
+				"limits": limits,
 			},
 		},
 	}
 
 	return resourceObj, nil
 }
+
+// limitRangeItem returns an item of a LimitRange of a given type, given the values of each of its
+// constraints keyed by the name of the resource.  Empty values and constraints are omitted and nil
+// is returned when the item has no constraints.
+func limitRangeItem(limitType string, constraints map[string]map[string]string) map[string]interface{} {
+	item := map[string]interface{}{}
+
+	for constraint, values := range constraints {
+		resources := map[string]interface{}{}
+
+		for resource, value := range values {
+			if value != "" {
+				resources[resource] = value
+			}
+		}
+
+		if len(resources) > 0 {
+			item[constraint] = resources
+		}
+	}
+
+	if len(item) == 0 {
+		return nil
+	}
+
+	item["type"] = limitType
+
+	return item
+}
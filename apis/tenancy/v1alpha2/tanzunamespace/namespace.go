@@ -4,12 +4,20 @@
 package tanzunamespace
 
 import (
+	"sort"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
 )
 
+// DefaultPodSecurity is the Pod Security Standards of a namespace when the podSecurity field is
+// omitted.  It is set from the command line of the operator.
+var DefaultPodSecurity *tenancyv1alpha2.TanzuNamespaceSpecPodSecurity
+
 // CreateNamespaceParentSpecNamespace creates the parent.Spec.Namespace Namespace resource.
 func CreateNamespaceParentSpecNamespace(
 	parent *tenancyv1alpha2.TanzuNamespace) (metav1.Object, error) {
@@ -25,5 +33,28 @@ func CreateNamespaceParentSpecNamespace(
 		},
 	}
 
+	// label the namespace with the pod security standards, which are tracked as managed labels
+	// so that they are removed from the namespace when they are no longer desired
+	podSecurity := parent.Spec.PodSecurity
+	if podSecurity == nil {
+		podSecurity = DefaultPodSecurity
+	}
+
+	if podSecurity != nil {
+		if labels := podSecurity.GetLabels(); len(labels) > 0 {
+			managed := make([]string, 0, len(labels))
+			for key := range labels {
+				managed = append(managed, key)
+			}
+
+			sort.Strings(managed)
+
+			resourceObj.SetLabels(labels)
+			resourceObj.SetAnnotations(map[string]string{
+				common.ManagedLabelsAnnotation: strings.Join(managed, ","),
+			})
+		}
+	}
+
 	return resourceObj, nil
 }
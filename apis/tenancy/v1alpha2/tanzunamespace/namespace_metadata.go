@@ -0,0 +1,96 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tanzunamespace
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+// SetNamespaceMetadata sets the labels and annotations of the namespaceMetadata field on the
+// Namespace resource and, when they are propagated, on each of the other resources.  The keys of
+// the labels and annotations are recorded in annotations on each resource so that keys which are
+// removed from the namespaceMetadata field may be removed from the resource.  Keys with reserved
+// prefixes are skipped.
+func SetNamespaceMetadata(parent *tenancyv1alpha2.TanzuNamespace, resourceObjs []metav1.Object) {
+	metadata := parent.Spec.NamespaceMetadata
+
+	for _, resourceObj := range resourceObjs {
+		resource, ok := resourceObj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		isNamespace := resource.GetKind() == "Namespace" && resource.GetName() == parent.Spec.Namespace
+		if !isNamespace && !metadata.PropagateToChildren {
+			continue
+		}
+
+		labels := resource.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		annotations := resource.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		setManaged(annotations, common.ManagedLabelsAnnotation, mergeMetadata(labels, metadata.Labels))
+		setManaged(annotations, common.ManagedAnnotationsAnnotation, mergeMetadata(annotations, metadata.Annotations))
+
+		if len(labels) > 0 {
+			resource.SetLabels(labels)
+		}
+
+		if len(annotations) > 0 {
+			resource.SetAnnotations(annotations)
+		}
+	}
+}
+
+// mergeMetadata merges the desired labels or annotations into the labels or annotations of a
+// resource, skipping reserved keys and keys which are already set on the resource, and returns
+// the sorted keys which were merged.
+func mergeMetadata(existing, desired map[string]string) []string {
+	var managed []string
+
+	for key, value := range desired {
+		if tenancyv1alpha2.IsReservedMetadataKey(key) {
+			continue
+		}
+
+		if _, found := existing[key]; found {
+			continue
+		}
+
+		existing[key] = value
+		managed = append(managed, key)
+	}
+
+	sort.Strings(managed)
+
+	return managed
+}
+
+// setManaged adds keys to the keys which are recorded in a managed labels or annotations
+// annotation.
+func setManaged(annotations map[string]string, managedAnnotation string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if existing := annotations[managedAnnotation]; existing != "" {
+		keys = append(strings.Split(existing, ","), keys...)
+	}
+
+	sort.Strings(keys)
+	annotations[managedAnnotation] = strings.Join(keys, ",")
+}
@@ -4,6 +4,8 @@
 package tanzunamespace
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -45,3 +47,179 @@ func CreateNetworkPolicyTanzuNetworkPolicy(
 
 	return resourceObj, nil
 }
+
+// CreateNetworkPoliciesTanzuNetworkPolicies creates a NetworkPolicy resource for each of the entries
+// in the networkPolicies field.
+func CreateNetworkPoliciesTanzuNetworkPolicies(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	for _, networkPolicy := range parent.Spec.NetworkPolicies {
+		policyTypes := []interface{}{}
+
+		spec := map[string]interface{}{
+			"podSelector": labelSelector(networkPolicy.TargetPodLabels),
+		}
+
+		if len(networkPolicy.Ingress) > 0 {
+			ingress, err := networkPolicyRules(networkPolicy.Name, "from", networkPolicy.Ingress)
+			if err != nil {
+				return nil, err
+			}
+
+			spec["ingress"] = ingress
+			policyTypes = append(policyTypes, "Ingress")
+		}
+
+		if len(networkPolicy.Egress) > 0 {
+			egress, err := networkPolicyRules(networkPolicy.Name, "to", networkPolicy.Egress)
+			if err != nil {
+				return nil, err
+			}
+
+			spec["egress"] = egress
+			policyTypes = append(policyTypes, "Egress")
+		}
+
+		if len(policyTypes) > 0 {
+			spec["policyTypes"] = policyTypes
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "networking.k8s.io/v1",
+				"kind":       "NetworkPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "tanzu-network-policy-" + networkPolicy.Name,
+					"namespace": parent.Spec.Namespace,
+				},
+				"spec": spec,
+			},
+		}
+
+		resourceObjs = append(resourceObjs, resourceObj)
+	}
+
+	return resourceObjs, nil
+}
+
+// networkPolicyRules converts the rules of a network policy into ingress or egress rules, using the
+// peerField (from or to) to list the peers of each rule.
+func networkPolicyRules(
+	name, peerField string,
+	rules []tenancyv1alpha2.TanzuNamespaceSpecNetworkPolicyRule) ([]interface{}, error) {
+	networkPolicyRules := []interface{}{}
+
+	for _, rule := range rules {
+		networkPolicyRule := map[string]interface{}{}
+
+		peers := []interface{}{}
+
+		if rule.SameNamespace {
+			peers = append(peers, map[string]interface{}{
+				"podSelector": map[string]interface{}{},
+			})
+		}
+
+		for _, peer := range rule.Peers {
+			networkPolicyPeer, err := networkPolicyPeer(name, peer)
+			if err != nil {
+				return nil, err
+			}
+
+			peers = append(peers, networkPolicyPeer)
+		}
+
+		if len(peers) > 0 {
+			networkPolicyRule[peerField] = peers
+		}
+
+		if len(rule.Ports) > 0 {
+			ports := []interface{}{}
+
+			for _, port := range rule.Ports {
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = "TCP"
+				}
+
+				ports = append(ports, map[string]interface{}{
+					"protocol": protocol,
+					"port":     int64(port.Port),
+				})
+			}
+
+			networkPolicyRule["ports"] = ports
+		}
+
+		networkPolicyRules = append(networkPolicyRules, networkPolicyRule)
+	}
+
+	return networkPolicyRules, nil
+}
+
+// networkPolicyPeer converts a peer of a network policy rule into a network policy peer.
+func networkPolicyPeer(
+	name string,
+	peer tenancyv1alpha2.TanzuNamespaceSpecNetworkPolicyPeer) (map[string]interface{}, error) {
+	if peer.CIDR != "" {
+		if peer.NamespaceLabels != nil || peer.PodLabels != nil {
+			return nil, fmt.Errorf(
+				"network policy %s has a peer with cidr %s; cidr may not be combined with namespaceLabels or podLabels",
+				name,
+				peer.CIDR,
+			)
+		}
+
+		ipBlock := map[string]interface{}{
+			"cidr": peer.CIDR,
+		}
+
+		if len(peer.Except) > 0 {
+			except := []interface{}{}
+			for _, cidr := range peer.Except {
+				except = append(except, cidr)
+			}
+
+			ipBlock["except"] = except
+		}
+
+		return map[string]interface{}{"ipBlock": ipBlock}, nil
+	}
+
+	if peer.NamespaceLabels == nil && peer.PodLabels == nil {
+		return nil, fmt.Errorf(
+			"network policy %s has a peer without a selector; one of cidr, namespaceLabels or podLabels is required",
+			name,
+		)
+	}
+
+	networkPolicyPeer := map[string]interface{}{}
+
+	if peer.NamespaceLabels != nil {
+		networkPolicyPeer["namespaceSelector"] = labelSelector(peer.NamespaceLabels)
+	}
+
+	if peer.PodLabels != nil {
+		networkPolicyPeer["podSelector"] = labelSelector(peer.PodLabels)
+	}
+
+	return networkPolicyPeer, nil
+}
+
+// labelSelector converts a set of labels into a label selector.  An empty set of labels selects
+// all objects.
+func labelSelector(labels map[string]string) map[string]interface{} {
+	matchLabels := map[string]interface{}{}
+	for key, value := range labels {
+		matchLabels[key] = value
+	}
+
+	if len(matchLabels) == 0 {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"matchLabels": matchLabels,
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tanzunamespace
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+const rbacAPIGroup = "rbac.authorization.k8s.io"
+
+// roleClusterRoles are the ClusterRoles which the built-in roles that are not created as a Role are
+// bound to.  The operator is not permitted to escalate its own permissions, so a built-in role is
+// only created as a Role when the operator itself holds each of its permissions.
+var roleClusterRoles = map[tenancyv1alpha2.TanzuNamespaceRole]string{
+	tenancyv1alpha2.TanzuNamespaceRoleAdmin:  "admin",
+	tenancyv1alpha2.TanzuNamespaceRoleViewer: "view",
+}
+
+// rolePermissions are the rules which belong to each of the built-in roles which are created as a
+// Role.  The kubebuilder rbac markers of the TanzuNamespace controller must grant each of these
+// permissions to the operator.
+var rolePermissions = map[tenancyv1alpha2.TanzuNamespaceRole]interface{}{
+	tenancyv1alpha2.TanzuNamespaceRoleDeveloper: []interface{}{
+		map[string]interface{}{
+			"apiGroups": []interface{}{""},
+			"resources": []interface{}{
+				"configmaps",
+				"secrets",
+				"services",
+				"pods",
+				"persistentvolumeclaims",
+			},
+			"verbs": developerVerbs,
+		},
+		map[string]interface{}{
+			"apiGroups": []interface{}{""},
+			"resources": []interface{}{"pods/log"},
+			"verbs":     []interface{}{"get", "list", "watch"},
+		},
+		map[string]interface{}{
+			"apiGroups": []interface{}{"apps"},
+			"resources": []interface{}{
+				"deployments",
+				"statefulsets",
+				"daemonsets",
+				"replicasets",
+			},
+			"verbs": developerVerbs,
+		},
+		map[string]interface{}{
+			"apiGroups": []interface{}{"networking.k8s.io"},
+			"resources": []interface{}{
+				"ingresses",
+				"networkpolicies",
+			},
+			"verbs": developerVerbs,
+		},
+		map[string]interface{}{
+			"apiGroups": []interface{}{"batch"},
+			"resources": []interface{}{
+				"jobs",
+				"cronjobs",
+			},
+			"verbs": developerVerbs,
+		},
+	},
+}
+
+// developerVerbs are the verbs which the built-in developer role is granted on its resources.
+var developerVerbs = []interface{}{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// roleName returns the name of the Role resource for a built-in role.
+func roleName(role tenancyv1alpha2.TanzuNamespaceRole) string {
+	return fmt.Sprintf("tanzu-%s-role", role)
+}
+
+// roleBindingName returns the name of the RoleBinding resource for a binding.
+func roleBindingName(binding tenancyv1alpha2.TanzuNamespaceSpecRBACBinding) string {
+	return fmt.Sprintf("tanzu-rolebinding-%s", binding.Name)
+}
+
+// serviceAccountNamespace returns the namespace of a service account, defaulting to the namespace
+// of the parent.
+func serviceAccountNamespace(
+	parent *tenancyv1alpha2.TanzuNamespace,
+	serviceAccount tenancyv1alpha2.TanzuNamespaceSpecRBACServiceAccount) string {
+	if serviceAccount.Namespace != "" {
+		return serviceAccount.Namespace
+	}
+
+	return parent.Spec.Namespace
+}
+
+// CreateServiceAccountsTanzuRBAC creates the ServiceAccount resources which are requested by the
+// rbac.bindings field.
+func CreateServiceAccountsTanzuRBAC(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	created := map[string]bool{}
+
+	for _, binding := range parent.Spec.RBAC.Bindings {
+		for _, serviceAccount := range binding.ServiceAccounts {
+			if !serviceAccount.Create || created[serviceAccount.Name] {
+				continue
+			}
+
+			if serviceAccountNamespace(parent, serviceAccount) != parent.Spec.Namespace {
+				return nil, fmt.Errorf(
+					"unable to create service account %s; service accounts may only be created in namespace %s",
+					serviceAccount.Name,
+					parent.Spec.Namespace,
+				)
+			}
+
+			var resourceObj = &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ServiceAccount",
+					"metadata": map[string]interface{}{
+						"name":      serviceAccount.Name,
+						"namespace": parent.Spec.Namespace,
+					},
+				},
+			}
+
+			created[serviceAccount.Name] = true
+			resourceObjs = append(resourceObjs, resourceObj)
+		}
+	}
+
+	return resourceObjs, nil
+}
+
+// CreateRolesTanzuRBAC creates a Role resource for each built-in role which is referenced by
+// the rbac.bindings field.
+func CreateRolesTanzuRBAC(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	created := map[tenancyv1alpha2.TanzuNamespaceRole]bool{}
+
+	for _, binding := range parent.Spec.RBAC.Bindings {
+		if binding.Role == "" || created[binding.Role] {
+			continue
+		}
+
+		if _, ok := roleClusterRoles[binding.Role]; ok {
+			continue
+		}
+
+		rules, ok := rolePermissions[binding.Role]
+		if !ok {
+			return nil, fmt.Errorf("unknown role %s in binding %s", binding.Role, binding.Name)
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": rbacAPIGroup + "/v1",
+				"kind":       "Role",
+				"metadata": map[string]interface{}{
+					"name":      roleName(binding.Role),
+					"namespace": parent.Spec.Namespace,
+				},
+				// copy the rules so that the built-in permissions are never modified by consumers
+				"rules": runtime.DeepCopyJSONValue(rules),
+			},
+		}
+
+		created[binding.Role] = true
+		resourceObjs = append(resourceObjs, resourceObj)
+	}
+
+	return resourceObjs, nil
+}
+
+// CreateRoleBindingsTanzuRBAC creates a RoleBinding resource for each of the entries in the
+// rbac.bindings field.
+func CreateRoleBindingsTanzuRBAC(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	for _, binding := range parent.Spec.RBAC.Bindings {
+		var roleRef map[string]interface{}
+
+		switch {
+		case binding.Role != "" && binding.ClusterRole != "":
+			return nil, fmt.Errorf("binding %s must specify only one of role or clusterRole", binding.Name)
+		case binding.Role != "":
+			roleRef = map[string]interface{}{
+				"apiGroup": rbacAPIGroup,
+				"kind":     "Role",
+				"name":     roleName(binding.Role),
+			}
+
+			if clusterRole, ok := roleClusterRoles[binding.Role]; ok {
+				roleRef["kind"] = "ClusterRole"
+				roleRef["name"] = clusterRole
+			}
+		case binding.ClusterRole != "":
+			if !tenancyv1alpha2.IsAllowedClusterRole(binding.ClusterRole) {
+				return nil, fmt.Errorf("binding %s references cluster role %s which is not one of the allowed cluster roles %v",
+					binding.Name, binding.ClusterRole, tenancyv1alpha2.AllowedClusterRoles)
+			}
+
+			roleRef = map[string]interface{}{
+				"apiGroup": rbacAPIGroup,
+				"kind":     "ClusterRole",
+				"name":     binding.ClusterRole,
+			}
+		default:
+			return nil, fmt.Errorf("binding %s must specify one of role or clusterRole", binding.Name)
+		}
+
+		subjects := []interface{}{}
+
+		for _, user := range binding.Users {
+			subjects = append(subjects, map[string]interface{}{
+				"apiGroup": rbacAPIGroup,
+				"kind":     "User",
+				"name":     user,
+			})
+		}
+
+		for _, group := range binding.Groups {
+			subjects = append(subjects, map[string]interface{}{
+				"apiGroup": rbacAPIGroup,
+				"kind":     "Group",
+				"name":     group,
+			})
+		}
+
+		for _, serviceAccount := range binding.ServiceAccounts {
+			subjects = append(subjects, map[string]interface{}{
+				"kind":      "ServiceAccount",
+				"name":      serviceAccount.Name,
+				"namespace": serviceAccountNamespace(parent, serviceAccount),
+			})
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": rbacAPIGroup + "/v1",
+				"kind":       "RoleBinding",
+				"metadata": map[string]interface{}{
+					"name":      roleBindingName(binding),
+					"namespace": parent.Spec.Namespace,
+				},
+				"roleRef":  roleRef,
+				"subjects": subjects,
+			},
+		}
+
+		resourceObjs = append(resourceObjs, resourceObj)
+	}
+
+	return resourceObjs, nil
+}
@@ -13,28 +13,96 @@ import (
 // CreateResourceQuotaTanzuResourceQuota creates the tanzu-resource-quota ResourceQuota resource.
 func CreateResourceQuotaTanzuResourceQuota(
 	parent *tenancyv1alpha2.TanzuNamespace) (metav1.Object, error) {
+	hard := map[string]interface{}{}
+	for resource, value := range parent.Spec.Resources.Quota.GetHard() {
+		hard[resource] = value
+	}
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "v1",
 			"kind":       "ResourceQuota",
 			"metadata": map[string]interface{}{
-				"name":      "tanzu-resource-quota",
+				"name":      tenancyv1alpha2.ResourceQuotaName,
 				"namespace": parent.Spec.Namespace,
 			},
 			"spec": map[string]interface{}{
-				"hard": map[string]interface{}{
-					// Default CPU requests quota to be enforced on the sum of all applications which get deployed into this namespace., controlled by resources.quota.requests.cpu
-					"requests.cpu": parent.Spec.Resources.Quota.Requests.Cpu,
-					// Default Memory requests quota to be enforced on the sum of all applications which get deployed into this namespace., controlled by resources.quota.requests.memory
-					"requests.memory": parent.Spec.Resources.Quota.Requests.Memory,
-					// Default CPU limits quota to be enforced on the sum of all applications which get deployed into this namespace., controlled by resources.quota.limits.cpu
-					"limits.cpu": parent.Spec.Resources.Quota.Limits.Cpu,
-					// Default Memory limits quota to be enforced on the sum of all applications which get deployed into this namespace., controlled by resources.quota.limits.memory
-					"limits.memory": parent.Spec.Resources.Quota.Limits.Memory,
-				},
+				// controlled by resources.quota, see TanzuNamespaceSpecResourcesQuota.GetHard
+				"hard": hard,
 			},
 		},
 	}
 
 	return resourceObj, nil
 }
+
+// CreateResourceQuotasTanzuScopedQuotas creates a ResourceQuota resource for each of the scoped
+// quotas.
+func CreateResourceQuotasTanzuScopedQuotas(
+	parent *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
+	var resourceObjs []metav1.Object
+
+	for i := range parent.Spec.Resources.ScopedQuotas {
+		scopedQuota := &parent.Spec.Resources.ScopedQuotas[i]
+
+		hard := map[string]interface{}{}
+		for resource, value := range scopedQuota.Hard {
+			hard[resource] = value
+		}
+
+		spec := map[string]interface{}{
+			"hard": hard,
+		}
+
+		if len(scopedQuota.Scopes) > 0 {
+			scopes := make([]interface{}, len(scopedQuota.Scopes))
+			for i, scope := range scopedQuota.Scopes {
+				scopes[i] = string(scope)
+			}
+
+			spec["scopes"] = scopes
+		}
+
+		if scopedQuota.ScopeSelector != nil && len(scopedQuota.ScopeSelector.MatchExpressions) > 0 {
+			matchExpressions := make([]interface{}, len(scopedQuota.ScopeSelector.MatchExpressions))
+
+			for i, requirement := range scopedQuota.ScopeSelector.MatchExpressions {
+				matchExpression := map[string]interface{}{
+					"scopeName": string(requirement.ScopeName),
+					"operator":  string(requirement.Operator),
+				}
+
+				if len(requirement.Values) > 0 {
+					values := make([]interface{}, len(requirement.Values))
+					for j, value := range requirement.Values {
+						values[j] = value
+					}
+
+					matchExpression["values"] = values
+				}
+
+				matchExpressions[i] = matchExpression
+			}
+
+			spec["scopeSelector"] = map[string]interface{}{
+				"matchExpressions": matchExpressions,
+			}
+		}
+
+		var resourceObj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ResourceQuota",
+				"metadata": map[string]interface{}{
+					"name":      scopedQuota.GetResourceQuotaName(),
+					"namespace": parent.Spec.Namespace,
+				},
+				"spec": spec,
+			},
+		}
+
+		resourceObjs = append(resourceObjs, resourceObj)
+	}
+
+	return resourceObjs, nil
+}
@@ -20,6 +20,20 @@ var CreateFuncs = []func(
 	CreateNetworkPolicyTanzuNetworkPolicy,
 }
 
+// CreateArrayFuncs is an array of functions that are called to create child resources for the controller
+// in memory during the reconciliation loop, where a single function may produce any number of resources
+// based on the contents of the parent.  They are called after the CreateFuncs.
+var CreateArrayFuncs = []func(
+	*tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error){
+	CreateServiceAccountsTanzuRBAC,
+	CreateRolesTanzuRBAC,
+	CreateRoleBindingsTanzuRBAC,
+	CreateResourceQuotasTanzuScopedQuotas,
+	CreateNetworkPoliciesTanzuNetworkPolicies,
+	CreateImagePullSecretsTanzuImagePullSecrets,
+	CreateServiceAccountTanzuImagePullSecrets,
+}
+
 // InitFuncs is an array of functions that are called prior to starting the controller manager.  This is
 // necessary in instances which the controller needs to "own" objects which depend on resources to
 // pre-exist in the cluster. A common use case for this is the need to own a custom resource.
@@ -0,0 +1,8 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1alpha2
+
+// Hub marks the v1alpha2 TanzuNamespace as the hub for conversion.  All other versions of the
+// TanzuNamespace are converted to and from this version.
+func (*TanzuNamespace) Hub() {}
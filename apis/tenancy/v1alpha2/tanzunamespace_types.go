@@ -4,8 +4,12 @@
 package v1alpha2
 
 import (
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -25,8 +29,176 @@ type TanzuNamespaceSpec struct {
 	Namespace string `json:"namespace"`
 
 	Resources TanzuNamespaceSpecResources `json:"resources"`
+
+	// +kubebuilder:validation:Optional
+	// Role-based access control which binds users, groups and service accounts to roles
+	// within the namespace.
+	RBAC TanzuNamespaceSpecRBAC `json:"rbac,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Network policies which allow traffic to and from pods within the namespace.  The default
+	// policy, which denies all traffic other than DNS queries, is always created.  Each entry
+	// produces an additional NetworkPolicy named tanzu-network-policy-<name>.
+	NetworkPolicies []TanzuNamespaceSpecNetworkPolicy `json:"networkPolicies,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image pull secrets which are copied into the namespace from source secrets which are
+	// stored in the namespace of the operator.  Each image pull secret is referenced by the
+	// default ServiceAccount of the namespace.
+	ImagePullSecrets []TanzuNamespaceSpecImagePullSecret `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Optional
+	// Policy which determines what happens to the namespace and its policy objects when the
+	// TanzuNamespace is deleted.  Delete removes them along with the TanzuNamespace, Retain keeps
+	// them, and RetainIfNotEmpty keeps them only if the namespace contains pods or persistent
+	// volume claims.
+	DeletionPolicy common.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// +kubebuilder:default=Never
+	// +kubebuilder:validation:Optional
+	// Policy which determines whether a namespace and policy objects which already exist are
+	// brought under management.  Never reports existing objects as conflicts, IfUnowned adopts
+	// existing objects which are not controlled by another owner, and Force adopts existing
+	// objects regardless of their owner.  Conflicts are reported in status before any object
+	// is modified.
+	Adoption common.AdoptionPolicy `json:"adoption,omitempty"`
+
+	// +kubebuilder:default=Enforce
+	// +kubebuilder:validation:Optional
+	// Policy which determines what happens when the namespace or a policy object is modified or
+	// deleted by something other than the operator.  Enforce restores the object and Report only
+	// reports the drift.  Drift is reported in status.
+	DriftPolicy common.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Labels and annotations which are set on the namespace and, optionally, on each of its
+	// policy objects.
+	NamespaceMetadata TanzuNamespaceSpecNamespaceMetadata `json:"namespaceMetadata,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Pod Security Standards which are enforced, audited and warned about within the namespace.
+	// When omitted, the default Pod Security Standards of the operator are used.
+	PodSecurity *TanzuNamespaceSpecPodSecurity `json:"podSecurity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Suspends the reconciliation of the TanzuNamespace, so that the namespace and its policy
+	// objects are not modified by the operator until it is cleared.  Reconciliation may also be
+	// suspended by setting the tenancy.platform.cnr.vmware.com/suspend annotation to "true".
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// TanzuNamespaceSpecNamespaceMetadata defines the labels and annotations of a namespace.  Keys
+// with the kubernetes.io/, k8s.io/ and tenancy.platform.cnr.vmware.com/ prefixes are reserved and
+// may not be set.
+type TanzuNamespaceSpecNamespaceMetadata struct {
+	// +kubebuilder:validation:Optional
+	// Labels which are set on the namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Annotations which are set on the namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Whether the labels and annotations are also set on each of the policy objects, such as the
+	// LimitRange, ResourceQuota and NetworkPolicy objects, within the namespace.
+	PropagateToChildren bool `json:"propagateToChildren,omitempty"`
+}
+
+// PodSecurityLevel defines a level of the Pod Security Standards.
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityLevel string
+
+const (
+	PodSecurityLevelPrivileged PodSecurityLevel = "privileged"
+	PodSecurityLevelBaseline   PodSecurityLevel = "baseline"
+	PodSecurityLevelRestricted PodSecurityLevel = "restricted"
+)
+
+// PodSecurityLabelPrefix is the prefix of the namespace labels which configure Pod Security
+// Admission.
+const PodSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// TanzuNamespaceSpecPodSecurity defines the Pod Security Standards of a namespace, which are
+// translated into the pod-security.kubernetes.io labels of the namespace.  Modes which are not
+// set are not labelled, in which case the cluster default applies.
+type TanzuNamespaceSpecPodSecurity struct {
+	// +kubebuilder:validation:Optional
+	// Level of the Pod Security Standards which pods are rejected for violating.
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(latest|v[0-9]+\.[0-9]+)$`
+	// Kubernetes version of the Pod Security Standards which are enforced, e.g. latest or v1.25.
+	EnforceVersion string `json:"enforceVersion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Level of the Pod Security Standards which violations are recorded in the audit log for.
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(latest|v[0-9]+\.[0-9]+)$`
+	// Kubernetes version of the Pod Security Standards which are audited, e.g. latest or v1.25.
+	AuditVersion string `json:"auditVersion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Level of the Pod Security Standards which users are warned about violating.
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(latest|v[0-9]+\.[0-9]+)$`
+	// Kubernetes version of the Pod Security Standards which are warned about, e.g. latest or
+	// v1.25.
+	WarnVersion string `json:"warnVersion,omitempty"`
+}
+
+// GetLabels returns the pod-security.kubernetes.io labels of the Pod Security Standards.
+func (podSecurity *TanzuNamespaceSpecPodSecurity) GetLabels() map[string]string {
+	labels := map[string]string{}
+
+	for mode, values := range map[string][2]string{
+		"enforce": {string(podSecurity.Enforce), podSecurity.EnforceVersion},
+		"audit":   {string(podSecurity.Audit), podSecurity.AuditVersion},
+		"warn":    {string(podSecurity.Warn), podSecurity.WarnVersion},
+	} {
+		if values[0] != "" {
+			labels[PodSecurityLabelPrefix+mode] = values[0]
+		}
+
+		if values[1] != "" {
+			labels[PodSecurityLabelPrefix+mode+"-version"] = values[1]
+		}
+	}
+
+	return labels
+}
+
+// ReservedMetadataPrefixes are the prefixes of the keys of labels and annotations which are
+// reserved for Kubernetes and for the operator, and which may not be set in namespaceMetadata.
+// Pod Security labels are reserved as they are set from the podSecurity field.
+var ReservedMetadataPrefixes = []string{
+	"kubernetes.io/",
+	"k8s.io/",
+	PodSecurityLabelPrefix,
+	"tenancy.platform.cnr.vmware.com/",
 }
 
+// IsReservedMetadataKey returns whether the key of a label or annotation has a reserved prefix.
+func IsReservedMetadataKey(key string) bool {
+	for _, prefix := range ReservedMetadataPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourceQuotaName is the name of the ResourceQuota of a TanzuNamespace, which is also the prefix
+// of the names of the ResourceQuotas of its scoped quotas.
+const ResourceQuotaName = "tanzu-resource-quota"
+
 type TanzuNamespaceSpecResources struct {
 	Limits TanzuNamespaceSpecResourcesLimits `json:"limits"`
 
@@ -34,7 +206,55 @@ type TanzuNamespaceSpecResources struct {
 
 	Max TanzuNamespaceSpecResourcesMax `json:"max"`
 
+	// +kubebuilder:validation:Optional
+	// Minimum requests for an individual application which get deployed into this namespace.
+	Min TanzuNamespaceSpecResourcesMin `json:"min,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum ratio of the limits to the requests of an individual application which get deployed
+	// into this namespace.
+	MaxLimitRequestRatio TanzuNamespaceSpecResourcesMaxLimitRequestRatio `json:"maxLimitRequestRatio,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Minimum and maximum resources of the sum of the applications within an individual pod.
+	Pod TanzuNamespaceSpecResourcesPod `json:"pod,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Minimum and maximum storage of an individual persistent volume claim.
+	PersistentVolumeClaim TanzuNamespaceSpecResourcesPersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+
 	Quota TanzuNamespaceSpecResourcesQuota `json:"quota"`
+
+	// +kubebuilder:validation:Optional
+	// Additional quotas which only apply to the objects matched by their scopes, e.g. the pods of a
+	// priority class.  Each entry produces an additional ResourceQuota named
+	// tanzu-resource-quota-<name>.
+	ScopedQuotas []TanzuNamespaceSpecResourcesScopedQuota `json:"scopedQuotas,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesScopedQuota struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name of the scoped quota, which is used to name the resulting ResourceQuota.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinProperties=1
+	// Hard limits of the scoped quota, keyed by the name of the resource as it appears in the
+	// ResourceQuota.
+	Hard map[string]string `json:"hard"`
+
+	// +kubebuilder:validation:Optional
+	// Scopes which must all match the objects which are tracked by the quota.
+	Scopes []corev1.ResourceQuotaScope `json:"scopes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Selector of the scopes, such as the priority classes, which must all match the objects which
+	// are tracked by the quota.
+	ScopeSelector *corev1.ScopeSelector `json:"scopeSelector,omitempty"`
+}
+
+// GetResourceQuotaName returns the name of the ResourceQuota which is produced by a scoped quota.
+func (scopedQuota *TanzuNamespaceSpecResourcesScopedQuota) GetResourceQuotaName() string {
+	return ResourceQuotaName + "-" + scopedQuota.Name
 }
 
 type TanzuNamespaceSpecResourcesLimits struct {
@@ -49,6 +269,11 @@ type TanzuNamespaceSpecResourcesLimits struct {
 	// Default Memory limits to be applied to applications which get deployed into this namespace,
 	// but are missing a resources declaration.
 	Memory string `json:"memory"`
+
+	// +kubebuilder:validation:Optional
+	// Default ephemeral storage limits to be applied to applications which get deployed into this
+	// namespace, but are missing a resources declaration.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
 }
 
 type TanzuNamespaceSpecResourcesRequests struct {
@@ -63,6 +288,11 @@ type TanzuNamespaceSpecResourcesRequests struct {
 	// Default Memory requests to be applied to applications which get deployed into this namespace,
 	// but are missing a resources declaration.
 	Memory string `json:"memory"`
+
+	// +kubebuilder:validation:Optional
+	// Default ephemeral storage requests to be applied to applications which get deployed into this
+	// namespace, but are missing a resources declaration.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
 }
 
 type TanzuNamespaceSpecResourcesMax struct {
@@ -75,12 +305,131 @@ type TanzuNamespaceSpecResourcesMax struct {
 	// +kubebuilder:validation:Optional
 	// Default maximum Memory limits for an individual application which get deployed into this namespace.
 	Memory string `json:"memory"`
+
+	// +kubebuilder:validation:Optional
+	// Default maximum ephemeral storage limits for an individual application which get deployed into this namespace.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesMin struct {
+	// +kubebuilder:validation:Optional
+	// Minimum CPU requests for an individual application which get deployed into this namespace.
+	Cpu string `json:"cpu,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Minimum Memory requests for an individual application which get deployed into this namespace.
+	Memory string `json:"memory,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Minimum ephemeral storage requests for an individual application which get deployed into this namespace.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesMaxLimitRequestRatio struct {
+	// +kubebuilder:validation:Optional
+	// Maximum ratio of the CPU limits to the CPU requests of an individual application.
+	Cpu string `json:"cpu,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum ratio of the Memory limits to the Memory requests of an individual application.
+	Memory string `json:"memory,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesPod struct {
+	// +kubebuilder:validation:Optional
+	// Minimum requests of the sum of the applications within an individual pod.
+	Min TanzuNamespaceSpecResourcesPodResources `json:"min,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum limits of the sum of the applications within an individual pod.
+	Max TanzuNamespaceSpecResourcesPodResources `json:"max,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesPodResources struct {
+	// +kubebuilder:validation:Optional
+	// CPU of the sum of the applications within an individual pod.
+	Cpu string `json:"cpu,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Memory of the sum of the applications within an individual pod.
+	Memory string `json:"memory,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ephemeral storage of the sum of the applications within an individual pod.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesPersistentVolumeClaim struct {
+	// +kubebuilder:validation:Optional
+	// Minimum storage which may be requested by an individual persistent volume claim.
+	Min string `json:"min,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum storage which may be requested by an individual persistent volume claim.
+	Max string `json:"max,omitempty"`
 }
 
 type TanzuNamespaceSpecResourcesQuota struct {
 	Requests TanzuNamespaceSpecResourcesQuotaRequests `json:"requests"`
 
 	Limits TanzuNamespaceSpecResourcesQuotaLimits `json:"limits"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of objects of each kind which may exist in this namespace.
+	Count TanzuNamespaceSpecResourcesQuotaCount `json:"count,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Storage quotas which are enforced on the persistent volume claims of a storage class.
+	StorageClasses []TanzuNamespaceSpecResourcesQuotaStorageClass `json:"storageClasses,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Requests quotas of extended resources, keyed by the name of the extended resource, e.g.
+	// nvidia.com/gpu.
+	ExtendedResources map[string]string `json:"extendedResources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Additional hard limits which are merged into the ResourceQuota, keyed by the name of the
+	// resource as it appears in the ResourceQuota.  Resources which are set by the other quota
+	// fields may not be set.
+	Hard map[string]string `json:"hard,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesQuotaCount struct {
+	// +kubebuilder:validation:Optional
+	// Maximum number of pods which may exist in this namespace.
+	Pods string `json:"pods,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of services which may exist in this namespace.
+	Services string `json:"services,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of services of type LoadBalancer which may exist in this namespace.
+	LoadBalancers string `json:"loadBalancers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of services of type NodePort which may exist in this namespace.
+	NodePorts string `json:"nodePorts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of persistent volume claims which may exist in this namespace.
+	PersistentVolumeClaims string `json:"persistentVolumeClaims,omitempty"`
+}
+
+type TanzuNamespaceSpecResourcesQuotaStorageClass struct {
+	// +kubebuilder:validation:Required
+	// Name of the storage class.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Storage requests quota to be enforced on the sum of the persistent volume claims of the
+	// storage class.
+	Storage string `json:"storage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum number of persistent volume claims of the storage class which may exist in this
+	// namespace.
+	PersistentVolumeClaims string `json:"persistentVolumeClaims,omitempty"`
 }
 
 type TanzuNamespaceSpecResourcesQuotaRequests struct {
@@ -93,6 +442,14 @@ type TanzuNamespaceSpecResourcesQuotaRequests struct {
 	// +kubebuilder:validation:Optional
 	// Default Memory requests quota to be enforced on the sum of all applications which get deployed into this namespace.
 	Memory string `json:"memory"`
+
+	// +kubebuilder:validation:Optional
+	// Storage requests quota to be enforced on the sum of all persistent volume claims in this namespace.
+	Storage string `json:"storage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ephemeral storage requests quota to be enforced on the sum of all applications which get deployed into this namespace.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
 }
 
 type TanzuNamespaceSpecResourcesQuotaLimits struct {
@@ -105,6 +462,213 @@ type TanzuNamespaceSpecResourcesQuotaLimits struct {
 	// +kubebuilder:validation:Optional
 	// Default Memory limits quota to be enforced on the sum of all applications which get deployed into this namespace.
 	Memory string `json:"memory"`
+
+	// +kubebuilder:validation:Optional
+	// Ephemeral storage limits quota to be enforced on the sum of all applications which get deployed into this namespace.
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+}
+
+// GetHard returns the hard limits of the ResourceQuota, keyed by the name of the resource.  The
+// hard limits of the typed quota fields are merged with the hard field, with the typed fields
+// taking precedence.  Empty limits are omitted, apart from the cpu and memory limits.
+func (quota *TanzuNamespaceSpecResourcesQuota) GetHard() map[string]string {
+	hard := map[string]string{}
+
+	for resource, value := range quota.Hard {
+		hard[resource] = value
+	}
+
+	hard["requests.cpu"] = quota.Requests.Cpu
+	hard["requests.memory"] = quota.Requests.Memory
+	hard["limits.cpu"] = quota.Limits.Cpu
+	hard["limits.memory"] = quota.Limits.Memory
+
+	for resource, value := range quota.typedHard() {
+		if value != "" {
+			hard[resource] = value
+		}
+	}
+
+	return hard
+}
+
+// typedHard returns the hard limits of the typed quota fields other than the cpu and memory
+// limits, including those which are empty.
+func (quota *TanzuNamespaceSpecResourcesQuota) typedHard() map[string]string {
+	hard := map[string]string{
+		"requests.storage":           quota.Requests.Storage,
+		"requests.ephemeral-storage": quota.Requests.EphemeralStorage,
+		"limits.ephemeral-storage":   quota.Limits.EphemeralStorage,
+		"pods":                       quota.Count.Pods,
+		"services":                   quota.Count.Services,
+		"services.loadbalancers":     quota.Count.LoadBalancers,
+		"services.nodeports":         quota.Count.NodePorts,
+		"persistentvolumeclaims":     quota.Count.PersistentVolumeClaims,
+	}
+
+	for _, storageClass := range quota.StorageClasses {
+		hard[storageClass.Name+".storageclass.storage.k8s.io/requests.storage"] = storageClass.Storage
+		hard[storageClass.Name+".storageclass.storage.k8s.io/persistentvolumeclaims"] = storageClass.PersistentVolumeClaims
+	}
+
+	for resource, value := range quota.ExtendedResources {
+		hard["requests."+resource] = value
+	}
+
+	return hard
+}
+
+// TanzuNamespaceRole defines a built-in role which is created within the namespace.
+// +kubebuilder:validation:Enum=admin;developer;viewer
+type TanzuNamespaceRole string
+
+const (
+	// TanzuNamespaceRoleAdmin grants full access to all resources within the namespace.
+	TanzuNamespaceRoleAdmin TanzuNamespaceRole = "admin"
+
+	// TanzuNamespaceRoleDeveloper grants access to manage common application resources within
+	// the namespace.
+	TanzuNamespaceRoleDeveloper TanzuNamespaceRole = "developer"
+
+	// TanzuNamespaceRoleViewer grants read-only access to all resources within the namespace.
+	TanzuNamespaceRoleViewer TanzuNamespaceRole = "viewer"
+)
+
+type TanzuNamespaceSpecRBAC struct {
+	// +kubebuilder:validation:Optional
+	// Bindings of users, groups and service accounts to roles within the namespace.  Each
+	// binding produces a RoleBinding named tanzu-rolebinding-<name>.
+	Bindings []TanzuNamespaceSpecRBACBinding `json:"bindings,omitempty"`
+}
+
+type TanzuNamespaceSpecRBACBinding struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name of the binding, which is used to name the resulting RoleBinding.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Built-in role which the subjects are bound to.  Exactly one of role or clusterRole
+	// must be specified.
+	Role TanzuNamespaceRole `json:"role,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name of an existing ClusterRole which the subjects are bound to within the namespace.
+	// Exactly one of role or clusterRole must be specified.  Only the ClusterRoles which are
+	// allowed by the operator, admin, edit and view by default, may be referenced.
+	ClusterRole string `json:"clusterRole,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Users which are bound to the role.
+	Users []string `json:"users,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Groups which are bound to the role.
+	Groups []string `json:"groups,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Service accounts which are bound to the role.
+	ServiceAccounts []TanzuNamespaceSpecRBACServiceAccount `json:"serviceAccounts,omitempty"`
+}
+
+type TanzuNamespaceSpecRBACServiceAccount struct {
+	// Name of the service account.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Namespace of the service account.  Defaults to the namespace of the TanzuNamespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Create the service account if it does not exist.  Only service accounts which reside in
+	// the namespace of the TanzuNamespace may be created.
+	Create bool `json:"create,omitempty"`
+}
+
+type TanzuNamespaceSpecNetworkPolicy struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name of the network policy, which is used to name the resulting NetworkPolicy.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Labels of the pods within the namespace which the network policy applies to.  The
+	// network policy applies to all pods within the namespace when empty.
+	TargetPodLabels map[string]string `json:"targetPodLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Rules which allow traffic into the target pods.
+	Ingress []TanzuNamespaceSpecNetworkPolicyRule `json:"ingress,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Rules which allow traffic out of the target pods.
+	Egress []TanzuNamespaceSpecNetworkPolicyRule `json:"egress,omitempty"`
+}
+
+type TanzuNamespaceSpecNetworkPolicyRule struct {
+	// +kubebuilder:validation:Optional
+	// Allow traffic from (ingress) or to (egress) all pods within the same namespace.
+	SameNamespace bool `json:"sameNamespace,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Peers which traffic is allowed from (ingress) or to (egress).  Traffic is allowed from or
+	// to all sources when no peers are specified and sameNamespace is false.
+	Peers []TanzuNamespaceSpecNetworkPolicyPeer `json:"peers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ports which traffic is allowed on.  Traffic is allowed on all ports when empty.
+	Ports []TanzuNamespaceSpecNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+type TanzuNamespaceSpecNetworkPolicyPeer struct {
+	// +kubebuilder:validation:Optional
+	// Labels of the namespaces which are selected by the peer.  An empty set of labels selects
+	// all namespaces.  May not be combined with cidr.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Labels of the pods which are selected by the peer.  Pods are selected from the namespace
+	// of the TanzuNamespace unless namespaceLabels is also specified.  May not be combined with cidr.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IP address range, in CIDR notation, which is selected by the peer.
+	CIDR string `json:"cidr,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IP address ranges, in CIDR notation, which are excluded from the cidr range.
+	Except []string `json:"except,omitempty"`
+}
+
+type TanzuNamespaceSpecNetworkPolicyPort struct {
+	// +kubebuilder:default=TCP
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	// Protocol which traffic is allowed on.
+	Protocol string `json:"protocol,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port which traffic is allowed on.
+	Port int32 `json:"port"`
+}
+
+type TanzuNamespaceSpecImagePullSecret struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-.a-z0-9]*[a-z0-9])?$`
+	// Name of the image pull secret which is created within the namespace.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Name of the source secret, of type kubernetes.io/dockerconfigjson, which is stored in the
+	// namespace of the operator.  Defaults to the name of the image pull secret.
+	SourceSecret string `json:"sourceSecret,omitempty"`
+}
+
+// GetSourceSecret returns the name of the source secret for an image pull secret.
+func (secret TanzuNamespaceSpecImagePullSecret) GetSourceSecret() string {
+	if secret.SourceSecret != "" {
+		return secret.SourceSecret
+	}
+
+	return secret.Name
 }
 
 // TanzuNamespaceStatus defines the observed state of TanzuNamespace.
@@ -112,16 +676,87 @@ type TanzuNamespaceStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	Created               bool                    `json:"created,omitempty"`
-	DependenciesSatisfied bool                    `json:"dependenciesSatisfied,omitempty"`
-	Conditions            []common.PhaseCondition `json:"conditions,omitempty"`
-	Resources             []common.Resource       `json:"resources,omitempty"`
+	Created               bool `json:"created,omitempty"`
+	DependenciesSatisfied bool `json:"dependenciesSatisfied,omitempty"`
+
+	// ObservedGeneration is the generation of the TanzuNamespace which was most recently reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are the conditions of each phase of the reconciliation.
+	Conditions []common.PhaseCondition `json:"conditions,omitempty"`
+
+	// StatusConditions are the standard conditions of the TanzuNamespace.  The Ready, Reconciling
+	// and Stalled conditions summarize the reconciliation, while a condition whose type is the name
+	// of a phase describes the most recent execution of that phase.  Stalled is only set when a
+	// phase fails with an error which is not resolved by retrying, such as an invalid spec or an
+	// adoption conflict.
+	// +listType=map
+	// +listMapKey=type
+	StatusConditions []metav1.Condition `json:"statusConditions,omitempty"`
+
+	// ResourceQuotas are the hard limits and current usage of each of the ResourceQuotas of the
+	// namespace, so that tenants may see which of their quotas have been exhausted.
+	ResourceQuotas []TanzuNamespaceStatusResourceQuota `json:"resourceQuotas,omitempty"`
+
+	// QuotaUsage is the utilization of the tanzu-resource-quota ResourceQuota of the namespace.
+	QuotaUsage *TanzuNamespaceStatusQuotaUsage `json:"quotaUsage,omitempty"`
+
+	Resources []common.Resource `json:"resources,omitempty"`
+}
+
+// TanzuNamespaceStatusResourceQuota is the observed state of a ResourceQuota of the namespace.
+type TanzuNamespaceStatusResourceQuota struct {
+	// Name of the ResourceQuota.
+	Name string `json:"name"`
+
+	// Hard limits of the ResourceQuota which are enforced.
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the current usage of each resource of the ResourceQuota.
+	Used corev1.ResourceList `json:"used,omitempty"`
+
+	// Exhausted are the resources whose usage has reached their hard limit.
+	Exhausted []string `json:"exhausted,omitempty"`
+}
+
+// TanzuNamespaceStatusQuotaUsage is the utilization of the tanzu-resource-quota ResourceQuota.
+type TanzuNamespaceStatusQuotaUsage struct {
+	// CPU is the highest utilization of the requests.cpu and limits.cpu resources, e.g. 45%.
+	CPU string `json:"cpu,omitempty"`
+
+	// Memory is the highest utilization of the requests.memory and limits.memory resources, e.g.
+	// 45%.
+	Memory string `json:"memory,omitempty"`
+
+	// Resources is the usage of each of the resources of the ResourceQuota.
+	Resources []TanzuNamespaceStatusQuotaUsageResource `json:"resources,omitempty"`
+}
+
+// TanzuNamespaceStatusQuotaUsageResource is the usage of a resource of a ResourceQuota.
+type TanzuNamespaceStatusQuotaUsageResource struct {
+	// Name of the resource.
+	Name string `json:"name"`
+
+	// Used is the current usage of the resource.
+	Used resource.Quantity `json:"used"`
+
+	// Hard is the hard limit of the resource.
+	Hard resource.Quantity `json:"hard"`
+
+	// Utilization is the percentage of the hard limit of the resource which is used.  It is
+	// omitted when the hard limit is zero.
+	Utilization *int64 `json:"utilization,omitempty"`
 }
 
 // +kubebuilder:storageversion
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.statusConditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="CPU",type=string,JSONPath=`.status.quotaUsage.cpu`
+// +kubebuilder:printcolumn:name="Memory",type=string,JSONPath=`.status.quotaUsage.memory`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // TanzuNamespace is the Schema for the tanzunamespaces API.
 type TanzuNamespace struct {
@@ -162,6 +797,38 @@ func (component *TanzuNamespace) SetDependencyStatus(dependencyStatus bool) {
 	component.Status.DependenciesSatisfied = dependencyStatus
 }
 
+// GetAdoptionPolicy returns the adoption policy for a component.
+func (component *TanzuNamespace) GetAdoptionPolicy() common.AdoptionPolicy {
+	if component.Spec.Adoption == "" {
+		return common.AdoptionPolicyNever
+	}
+
+	return component.Spec.Adoption
+}
+
+// GetDeletionPolicy returns the deletion policy for a component.
+func (component *TanzuNamespace) GetDeletionPolicy() common.DeletionPolicy {
+	if component.Spec.DeletionPolicy == "" {
+		return common.DeletionPolicyDelete
+	}
+
+	return component.Spec.DeletionPolicy
+}
+
+// GetDriftPolicy returns the drift policy for a component.
+func (component *TanzuNamespace) GetDriftPolicy() common.DriftPolicy {
+	if component.Spec.DriftPolicy == "" {
+		return common.DriftPolicyEnforce
+	}
+
+	return component.Spec.DriftPolicy
+}
+
+// IsSuspended returns whether the reconciliation of a component is suspended.
+func (component *TanzuNamespace) IsSuspended() bool {
+	return component.Spec.Suspend || component.Annotations[common.SuspendAnnotation] == "true"
+}
+
 // GetPhaseConditions returns the phase conditions for a component.
 func (component TanzuNamespace) GetPhaseConditions() []common.PhaseCondition {
 	return component.Status.Conditions
@@ -179,6 +846,21 @@ func (component *TanzuNamespace) SetPhaseCondition(condition common.PhaseConditi
 	}
 }
 
+// GetStatusConditions returns the standard conditions for a component.
+func (component *TanzuNamespace) GetStatusConditions() []metav1.Condition {
+	return component.Status.StatusConditions
+}
+
+// SetStatusCondition sets a standard condition for a component.
+func (component *TanzuNamespace) SetStatusCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&component.Status.StatusConditions, condition)
+}
+
+// SetObservedGeneration sets the most recently reconciled generation for a component.
+func (component *TanzuNamespace) SetObservedGeneration(generation int64) {
+	component.Status.ObservedGeneration = generation
+}
+
 // GetResources returns the resources for a component.
 func (component TanzuNamespace) GetResources() []common.Resource {
 	return component.Status.Resources
@@ -197,6 +879,13 @@ func (component *TanzuNamespace) SetResource(resource common.Resource) {
 	}
 }
 
+// RemoveResource removes a resource from the resources for a component.
+func (component *TanzuNamespace) RemoveResource(resource common.Resource) {
+	if found := resource.GetResourceIndex(component); found >= 0 {
+		component.Status.Resources = append(component.Status.Resources[:found], component.Status.Resources[found+1:]...)
+	}
+}
+
 // GetDependencies returns the dependencies for a component.
 func (*TanzuNamespace) GetDependencies() []common.Component {
 	return []common.Component{}
@@ -0,0 +1,593 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1alpha2
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// quantityField is a resource field of a TanzuNamespace which has been parsed as a quantity.
+type quantityField struct {
+	path     *field.Path
+	value    string
+	quantity resource.Quantity
+	parsed   bool
+}
+
+// newQuantityField parses the value of a resource field as a quantity, appending an error to the
+// list of errors if the value may not be parsed or is negative.  Empty values are not parsed.
+func newQuantityField(path *field.Path, value string, errs *field.ErrorList) *quantityField {
+	quantityField := &quantityField{path: path, value: value}
+
+	if value == "" {
+		return quantityField
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		*errs = append(*errs, field.Invalid(path, value, err.Error()))
+
+		return quantityField
+	}
+
+	if quantity.Sign() < 0 {
+		*errs = append(*errs, field.Invalid(path, value, "must be greater than or equal to 0"))
+
+		return quantityField
+	}
+
+	quantityField.quantity = quantity
+	quantityField.parsed = true
+
+	return quantityField
+}
+
+// validateLessThanOrEqual appends an error to the list of errors if the lesser field is greater
+// than the greater field.  The comparison is skipped if either field was not parsed.
+func validateLessThanOrEqual(lesser, greater *quantityField, errs *field.ErrorList) {
+	if !lesser.parsed || !greater.parsed {
+		return
+	}
+
+	if lesser.quantity.Cmp(greater.quantity) > 0 {
+		*errs = append(*errs, field.Invalid(
+			lesser.path,
+			lesser.value,
+			fmt.Sprintf("must be less than or equal to %s (%s)", greater.path, greater.value),
+		))
+	}
+}
+
+// Validate validates the spec of a TanzuNamespace, returning a list of errors for each of the
+// invalid fields.  Each resource field must be a valid quantity, default requests must not exceed
+// default limits, default limits must not exceed the maximum limits, the maximum limits must not
+// exceed the limits quota and the requests quota must not exceed the limits quota.  Minimum
+// requests must not exceed the default requests or the maximum limits.  The remaining fields of
+// the limit range and of the quota, along with the rbac, network policy and image pull secret
+// fields, are validated separately.
+func (component *TanzuNamespace) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	resourcesPath := field.NewPath("spec", "resources")
+
+	if component.Spec.Namespace == "" {
+		errs = append(errs, field.Required(field.NewPath("spec", "namespace"), "namespace is required"))
+	}
+
+	specResources := component.Spec.Resources
+
+	type quantityFields struct {
+		min, requests, limits, max, quotaRequests, quotaLimits *quantityField
+	}
+
+	for _, fields := range []quantityFields{
+		{
+			min:           newQuantityField(resourcesPath.Child("min", "cpu"), specResources.Min.Cpu, &errs),
+			requests:      newQuantityField(resourcesPath.Child("requests", "cpu"), specResources.Requests.Cpu, &errs),
+			limits:        newQuantityField(resourcesPath.Child("limits", "cpu"), specResources.Limits.Cpu, &errs),
+			max:           newQuantityField(resourcesPath.Child("max", "cpu"), specResources.Max.Cpu, &errs),
+			quotaRequests: newQuantityField(resourcesPath.Child("quota", "requests", "cpu"), specResources.Quota.Requests.Cpu, &errs),
+			quotaLimits:   newQuantityField(resourcesPath.Child("quota", "limits", "cpu"), specResources.Quota.Limits.Cpu, &errs),
+		},
+		{
+			min:           newQuantityField(resourcesPath.Child("min", "memory"), specResources.Min.Memory, &errs),
+			requests:      newQuantityField(resourcesPath.Child("requests", "memory"), specResources.Requests.Memory, &errs),
+			limits:        newQuantityField(resourcesPath.Child("limits", "memory"), specResources.Limits.Memory, &errs),
+			max:           newQuantityField(resourcesPath.Child("max", "memory"), specResources.Max.Memory, &errs),
+			quotaRequests: newQuantityField(resourcesPath.Child("quota", "requests", "memory"), specResources.Quota.Requests.Memory, &errs),
+			quotaLimits:   newQuantityField(resourcesPath.Child("quota", "limits", "memory"), specResources.Quota.Limits.Memory, &errs),
+		},
+		{
+			min:           newQuantityField(resourcesPath.Child("min", "ephemeralStorage"), specResources.Min.EphemeralStorage, &errs),
+			requests:      newQuantityField(resourcesPath.Child("requests", "ephemeralStorage"), specResources.Requests.EphemeralStorage, &errs),
+			limits:        newQuantityField(resourcesPath.Child("limits", "ephemeralStorage"), specResources.Limits.EphemeralStorage, &errs),
+			max:           newQuantityField(resourcesPath.Child("max", "ephemeralStorage"), specResources.Max.EphemeralStorage, &errs),
+			quotaRequests: newQuantityField(resourcesPath.Child("quota", "requests", "ephemeralStorage"), specResources.Quota.Requests.EphemeralStorage, &errs),
+			quotaLimits:   newQuantityField(resourcesPath.Child("quota", "limits", "ephemeralStorage"), specResources.Quota.Limits.EphemeralStorage, &errs),
+		},
+	} {
+		validateLessThanOrEqual(fields.min, fields.requests, &errs)
+		validateLessThanOrEqual(fields.min, fields.max, &errs)
+		validateLessThanOrEqual(fields.requests, fields.limits, &errs)
+		validateLessThanOrEqual(fields.limits, fields.max, &errs)
+		validateLessThanOrEqual(fields.max, fields.quotaLimits, &errs)
+		validateLessThanOrEqual(fields.quotaRequests, fields.quotaLimits, &errs)
+	}
+
+	errs = append(errs, specResources.validateLimitRange(resourcesPath)...)
+	errs = append(errs, specResources.Quota.Validate(resourcesPath.Child("quota"))...)
+	errs = append(errs, validateScopedQuotas(resourcesPath.Child("scopedQuotas"), specResources.ScopedQuotas)...)
+
+	errs = append(errs, component.validateNamespaceMetadata()...)
+	errs = append(errs, component.validateRBAC()...)
+	errs = append(errs, validateNetworkPolicies(field.NewPath("spec", "networkPolicies"), component.Spec.NetworkPolicies)...)
+	errs = append(errs, validateImagePullSecrets(field.NewPath("spec", "imagePullSecrets"), component.Spec.ImagePullSecrets)...)
+
+	if component.Spec.PodSecurity != nil {
+		errs = append(errs, component.Spec.PodSecurity.Validate(field.NewPath("spec", "podSecurity"))...)
+	}
+
+	return errs
+}
+
+// validateLimitRange validates the fields of the LimitRange which are not validated alongside the
+// quotas.  Ratios must be valid quantities of at least 1 and the minimums of pods and persistent
+// volume claims must not exceed their maximums.
+func (specResources *TanzuNamespaceSpecResources) validateLimitRange(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	one := resource.MustParse("1")
+
+	for _, ratioField := range []struct{ name, value string }{
+		{name: "cpu", value: specResources.MaxLimitRequestRatio.Cpu},
+		{name: "memory", value: specResources.MaxLimitRequestRatio.Memory},
+	} {
+		ratio := newQuantityField(path.Child("maxLimitRequestRatio", ratioField.name), ratioField.value, &errs)
+		if ratio.parsed && ratio.quantity.Cmp(one) < 0 {
+			errs = append(errs, field.Invalid(ratio.path, ratio.value, "must be greater than or equal to 1"))
+		}
+	}
+
+	podPath := path.Child("pod")
+	pod := specResources.Pod
+
+	validateLessThanOrEqual(
+		newQuantityField(podPath.Child("min", "cpu"), pod.Min.Cpu, &errs),
+		newQuantityField(podPath.Child("max", "cpu"), pod.Max.Cpu, &errs),
+		&errs,
+	)
+	validateLessThanOrEqual(
+		newQuantityField(podPath.Child("min", "memory"), pod.Min.Memory, &errs),
+		newQuantityField(podPath.Child("max", "memory"), pod.Max.Memory, &errs),
+		&errs,
+	)
+	validateLessThanOrEqual(
+		newQuantityField(podPath.Child("min", "ephemeralStorage"), pod.Min.EphemeralStorage, &errs),
+		newQuantityField(podPath.Child("max", "ephemeralStorage"), pod.Max.EphemeralStorage, &errs),
+		&errs,
+	)
+
+	validateLessThanOrEqual(
+		newQuantityField(path.Child("persistentVolumeClaim", "min"), specResources.PersistentVolumeClaim.Min, &errs),
+		newQuantityField(path.Child("persistentVolumeClaim", "max"), specResources.PersistentVolumeClaim.Max, &errs),
+		&errs,
+	)
+
+	return errs
+}
+
+// Validate validates the storage, object count, extended resource and hard fields of the
+// ResourceQuota.  Each value must be a valid quantity, storage class names must be unique and
+// resources which are set by the typed fields must not be set by the hard field.
+func (quota *TanzuNamespaceSpecResourcesQuota) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	newQuantityField(path.Child("requests", "storage"), quota.Requests.Storage, &errs)
+
+	countPath := path.Child("count")
+	newQuantityField(countPath.Child("pods"), quota.Count.Pods, &errs)
+	newQuantityField(countPath.Child("services"), quota.Count.Services, &errs)
+	newQuantityField(countPath.Child("loadBalancers"), quota.Count.LoadBalancers, &errs)
+	newQuantityField(countPath.Child("nodePorts"), quota.Count.NodePorts, &errs)
+	newQuantityField(countPath.Child("persistentVolumeClaims"), quota.Count.PersistentVolumeClaims, &errs)
+
+	storageClassNames := map[string]bool{}
+
+	for i, storageClass := range quota.StorageClasses {
+		storageClassPath := path.Child("storageClasses").Index(i)
+
+		for _, msg := range validation.IsDNS1123Subdomain(storageClass.Name) {
+			errs = append(errs, field.Invalid(storageClassPath.Child("name"), storageClass.Name, msg))
+		}
+
+		if storageClassNames[storageClass.Name] {
+			errs = append(errs, field.Duplicate(storageClassPath.Child("name"), storageClass.Name))
+		}
+
+		storageClassNames[storageClass.Name] = true
+
+		newQuantityField(storageClassPath.Child("storage"), storageClass.Storage, &errs)
+		newQuantityField(storageClassPath.Child("persistentVolumeClaims"), storageClass.PersistentVolumeClaims, &errs)
+	}
+
+	for _, name := range sortedKeys(quota.ExtendedResources) {
+		value := quota.ExtendedResources[name]
+		extendedResourcePath := path.Child("extendedResources").Key(name)
+
+		for _, msg := range validation.IsQualifiedName(name) {
+			errs = append(errs, field.Invalid(extendedResourcePath, name, msg))
+		}
+
+		if !strings.Contains(name, "/") || strings.HasPrefix(name, "kubernetes.io/") {
+			errs = append(errs, field.Invalid(extendedResourcePath, name,
+				"must be an extended resource name with a domain prefix other than kubernetes.io"))
+		}
+
+		newQuantityField(extendedResourcePath, value, &errs)
+	}
+
+	typedHard := quota.typedHard()
+
+	for _, name := range sortedKeys(quota.Hard) {
+		value := quota.Hard[name]
+		hardPath := path.Child("hard").Key(name)
+
+		switch name {
+		case "requests.cpu", "requests.memory", "limits.cpu", "limits.memory", "cpu", "memory":
+			errs = append(errs, field.Forbidden(hardPath, "cpu and memory quotas must be set with the requests and limits fields"))
+		default:
+			if typedHard[name] != "" {
+				errs = append(errs, field.Forbidden(hardPath, "resource is already set by another quota field"))
+			}
+		}
+
+		newQuantityField(hardPath, value, &errs)
+	}
+
+	return errs
+}
+
+// resourceQuotaScopes are the scopes which are supported by a ResourceQuota.
+var resourceQuotaScopes = []string{
+	string(corev1.ResourceQuotaScopeTerminating),
+	string(corev1.ResourceQuotaScopeNotTerminating),
+	string(corev1.ResourceQuotaScopeBestEffort),
+	string(corev1.ResourceQuotaScopeNotBestEffort),
+	string(corev1.ResourceQuotaScopePriorityClass),
+	string(corev1.ResourceQuotaScopeCrossNamespacePodAffinity),
+}
+
+// validateScopedQuotas validates the scoped quotas.  Names must be unique, each hard limit must be a
+// valid quantity and each scoped quota must have at least one scope.  Only the PriorityClass scope
+// may be selected with values.
+func validateScopedQuotas(path *field.Path, scopedQuotas []TanzuNamespaceSpecResourcesScopedQuota) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]bool{}
+
+	for i := range scopedQuotas {
+		scopedQuota := &scopedQuotas[i]
+		scopedQuotaPath := path.Index(i)
+
+		if names[scopedQuota.Name] {
+			errs = append(errs, field.Duplicate(scopedQuotaPath.Child("name"), scopedQuota.Name))
+		}
+
+		names[scopedQuota.Name] = true
+
+		if len(scopedQuota.Hard) == 0 {
+			errs = append(errs, field.Required(scopedQuotaPath.Child("hard"), "at least one hard limit is required"))
+		}
+
+		for _, name := range sortedKeys(scopedQuota.Hard) {
+			newQuantityField(scopedQuotaPath.Child("hard").Key(name), scopedQuota.Hard[name], &errs)
+		}
+
+		var matchExpressions []corev1.ScopedResourceSelectorRequirement
+		if scopedQuota.ScopeSelector != nil {
+			matchExpressions = scopedQuota.ScopeSelector.MatchExpressions
+		}
+
+		if len(scopedQuota.Scopes) == 0 && len(matchExpressions) == 0 {
+			errs = append(errs, field.Required(scopedQuotaPath, "at least one of scopes or scopeSelector is required"))
+		}
+
+		for j, scope := range scopedQuota.Scopes {
+			if !containsString(resourceQuotaScopes, string(scope)) {
+				errs = append(errs, field.NotSupported(scopedQuotaPath.Child("scopes").Index(j), scope, resourceQuotaScopes))
+			}
+		}
+
+		for j, requirement := range matchExpressions {
+			requirementPath := scopedQuotaPath.Child("scopeSelector", "matchExpressions").Index(j)
+
+			if !containsString(resourceQuotaScopes, string(requirement.ScopeName)) {
+				errs = append(errs, field.NotSupported(requirementPath.Child("scopeName"), requirement.ScopeName, resourceQuotaScopes))
+			}
+
+			switch requirement.Operator {
+			case corev1.ScopeSelectorOpIn, corev1.ScopeSelectorOpNotIn:
+				if requirement.ScopeName != corev1.ResourceQuotaScopePriorityClass {
+					errs = append(errs, field.Invalid(requirementPath.Child("operator"), requirement.Operator,
+						"may only be used with the PriorityClass scope"))
+				}
+
+				if len(requirement.Values) == 0 {
+					errs = append(errs, field.Required(requirementPath.Child("values"), "values are required for the In and NotIn operators"))
+				}
+			case corev1.ScopeSelectorOpExists, corev1.ScopeSelectorOpDoesNotExist:
+				if len(requirement.Values) > 0 {
+					errs = append(errs, field.Forbidden(requirementPath.Child("values"),
+						"values may not be set for the Exists and DoesNotExist operators"))
+				}
+			default:
+				errs = append(errs, field.NotSupported(requirementPath.Child("operator"), requirement.Operator, []string{
+					string(corev1.ScopeSelectorOpIn),
+					string(corev1.ScopeSelectorOpNotIn),
+					string(corev1.ScopeSelectorOpExists),
+					string(corev1.ScopeSelectorOpDoesNotExist),
+				}))
+			}
+		}
+	}
+
+	return errs
+}
+
+// AllowedClusterRoles are the names of the ClusterRoles which a binding may reference with the
+// clusterRole field.  It is set from the command line of the operator and must match the names of
+// the ClusterRoles which the operator is permitted to bind, as the operator would otherwise be able
+// to grant any permission to the subjects of a binding.
+var AllowedClusterRoles = []string{"admin", "edit", "view"}
+
+// IsAllowedClusterRole returns whether a binding may reference a ClusterRole.
+func IsAllowedClusterRole(name string) bool {
+	return containsString(AllowedClusterRoles, name)
+}
+
+// validateRBAC validates the bindings of the rbac field.  Names must be unique, each binding must
+// specify exactly one of role or clusterRole, a clusterRole must be one of the allowed ClusterRoles
+// and service accounts may only be created within the namespace of the TanzuNamespace.
+func (component *TanzuNamespace) validateRBAC() field.ErrorList {
+	var errs field.ErrorList
+
+	bindingsPath := field.NewPath("spec", "rbac", "bindings")
+	names := map[string]bool{}
+
+	for i, binding := range component.Spec.RBAC.Bindings {
+		bindingPath := bindingsPath.Index(i)
+
+		if names[binding.Name] {
+			errs = append(errs, field.Duplicate(bindingPath.Child("name"), binding.Name))
+		}
+
+		names[binding.Name] = true
+
+		switch {
+		case binding.Role != "" && binding.ClusterRole != "":
+			errs = append(errs, field.Invalid(bindingPath.Child("clusterRole"), binding.ClusterRole,
+				"may not be specified along with role; exactly one of role or clusterRole is required"))
+		case binding.Role == "" && binding.ClusterRole == "":
+			errs = append(errs, field.Required(bindingPath, "exactly one of role or clusterRole is required"))
+		case binding.ClusterRole != "" && !IsAllowedClusterRole(binding.ClusterRole):
+			errs = append(errs, field.NotSupported(bindingPath.Child("clusterRole"), binding.ClusterRole, AllowedClusterRoles))
+		}
+
+		for j, serviceAccount := range binding.ServiceAccounts {
+			if !serviceAccount.Create || serviceAccount.Namespace == "" || serviceAccount.Namespace == component.Spec.Namespace {
+				continue
+			}
+
+			errs = append(errs, field.Invalid(bindingPath.Child("serviceAccounts").Index(j).Child("namespace"), serviceAccount.Namespace,
+				fmt.Sprintf("service accounts may only be created in namespace %s", component.Spec.Namespace)))
+		}
+	}
+
+	return errs
+}
+
+// validateNetworkPolicies validates the networkPolicies field.  Names must be unique and each peer
+// must select either an IP address range, which must be valid CIDR notation, or namespaces and pods
+// by their labels, but not both.
+func validateNetworkPolicies(path *field.Path, networkPolicies []TanzuNamespaceSpecNetworkPolicy) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]bool{}
+
+	for i, networkPolicy := range networkPolicies {
+		networkPolicyPath := path.Index(i)
+
+		if names[networkPolicy.Name] {
+			errs = append(errs, field.Duplicate(networkPolicyPath.Child("name"), networkPolicy.Name))
+		}
+
+		names[networkPolicy.Name] = true
+
+		for j, rule := range networkPolicy.Ingress {
+			for k := range rule.Peers {
+				errs = append(errs, rule.Peers[k].validate(networkPolicyPath.Child("ingress").Index(j).Child("peers").Index(k))...)
+			}
+		}
+
+		for j, rule := range networkPolicy.Egress {
+			for k := range rule.Peers {
+				errs = append(errs, rule.Peers[k].validate(networkPolicyPath.Child("egress").Index(j).Child("peers").Index(k))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validate validates a peer of a network policy rule.
+func (peer *TanzuNamespaceSpecNetworkPolicyPeer) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if peer.CIDR == "" {
+		if peer.NamespaceLabels == nil && peer.PodLabels == nil {
+			errs = append(errs, field.Required(path, "one of cidr, namespaceLabels or podLabels is required"))
+		}
+
+		if len(peer.Except) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("except"), "except may only be specified along with cidr"))
+		}
+
+		return errs
+	}
+
+	if peer.NamespaceLabels != nil || peer.PodLabels != nil {
+		errs = append(errs, field.Invalid(path.Child("cidr"), peer.CIDR, "may not be combined with namespaceLabels or podLabels"))
+	}
+
+	if _, _, err := net.ParseCIDR(peer.CIDR); err != nil {
+		errs = append(errs, field.Invalid(path.Child("cidr"), peer.CIDR, "must be a valid IP address range in CIDR notation"))
+	}
+
+	for i, except := range peer.Except {
+		if _, _, err := net.ParseCIDR(except); err != nil {
+			errs = append(errs, field.Invalid(path.Child("except").Index(i), except, "must be a valid IP address range in CIDR notation"))
+		}
+	}
+
+	return errs
+}
+
+// validateImagePullSecrets validates the imagePullSecrets field.  Names must be unique.
+func validateImagePullSecrets(path *field.Path, imagePullSecrets []TanzuNamespaceSpecImagePullSecret) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]bool{}
+
+	for i, imagePullSecret := range imagePullSecrets {
+		if names[imagePullSecret.Name] {
+			errs = append(errs, field.Duplicate(path.Index(i).Child("name"), imagePullSecret.Name))
+		}
+
+		names[imagePullSecret.Name] = true
+	}
+
+	return errs
+}
+
+// sortedKeys returns the sorted keys of a map so that the errors for its entries are always
+// reported in the same order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// containsString returns whether a slice of strings contains a string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// podSecurityVersion matches the valid versions of the Pod Security Standards.
+var podSecurityVersion = regexp.MustCompile(`^(latest|v[0-9]+\.[0-9]+)$`)
+
+// Validate validates the levels and versions of the Pod Security Standards.
+func (podSecurity *TanzuNamespaceSpecPodSecurity) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	validLevels := []string{
+		string(PodSecurityLevelPrivileged),
+		string(PodSecurityLevelBaseline),
+		string(PodSecurityLevelRestricted),
+	}
+
+	for _, levelField := range []struct {
+		name  string
+		level PodSecurityLevel
+	}{
+		{name: "enforce", level: podSecurity.Enforce},
+		{name: "audit", level: podSecurity.Audit},
+		{name: "warn", level: podSecurity.Warn},
+	} {
+		level := levelField.level
+		if level == "" {
+			continue
+		}
+
+		if level != PodSecurityLevelPrivileged && level != PodSecurityLevelBaseline && level != PodSecurityLevelRestricted {
+			errs = append(errs, field.NotSupported(path.Child(levelField.name), level, validLevels))
+		}
+	}
+
+	for _, versionField := range []struct{ name, version string }{
+		{name: "enforceVersion", version: podSecurity.EnforceVersion},
+		{name: "auditVersion", version: podSecurity.AuditVersion},
+		{name: "warnVersion", version: podSecurity.WarnVersion},
+	} {
+		if versionField.version != "" && !podSecurityVersion.MatchString(versionField.version) {
+			errs = append(errs, field.Invalid(path.Child(versionField.name), versionField.version,
+				"must be latest or a Kubernetes minor version, e.g. v1.25"))
+		}
+	}
+
+	return errs
+}
+
+// validateNamespaceMetadata validates the labels and annotations of the namespaceMetadata field.
+// Keys must be qualified names which do not have a reserved prefix and label values must be valid
+// label values.
+func (component *TanzuNamespace) validateNamespaceMetadata() field.ErrorList {
+	var errs field.ErrorList
+
+	metadataPath := field.NewPath("spec", "namespaceMetadata")
+
+	for _, key := range sortedKeys(component.Spec.NamespaceMetadata.Labels) {
+		value := component.Spec.NamespaceMetadata.Labels[key]
+		keyPath := metadataPath.Child("labels").Key(key)
+
+		errs = append(errs, validateMetadataKey(keyPath, key)...)
+
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, field.Invalid(keyPath, value, msg))
+		}
+	}
+
+	for _, key := range sortedKeys(component.Spec.NamespaceMetadata.Annotations) {
+		errs = append(errs, validateMetadataKey(metadataPath.Child("annotations").Key(key), key)...)
+	}
+
+	return errs
+}
+
+// validateMetadataKey validates the key of a label or annotation.
+func validateMetadataKey(path *field.Path, key string) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, msg := range validation.IsQualifiedName(key) {
+		errs = append(errs, field.Invalid(path, key, msg))
+	}
+
+	switch {
+	case strings.HasPrefix(key, PodSecurityLabelPrefix):
+		errs = append(errs, field.Forbidden(path, "pod security labels must be set with spec.podSecurity"))
+	case IsReservedMetadataKey(key):
+		errs = append(errs, field.Forbidden(path, fmt.Sprintf("keys with the prefixes %v are reserved", ReservedMetadataPrefixes)))
+	}
+
+	return errs
+}
@@ -0,0 +1,375 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1alpha2
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestTanzuNamespace_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mutate func(component *TanzuNamespace)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(component *TanzuNamespace) {},
+			want:   nil,
+		},
+		{
+			name: "missing namespace",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Namespace = ""
+			},
+			want: []string{"FieldValueRequired spec.namespace"},
+		},
+		{
+			name: "invalid quantity",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Requests.Cpu = "one"
+			},
+			want: []string{"FieldValueInvalid spec.resources.requests.cpu"},
+		},
+		{
+			name: "negative quantity",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Quota.Count.Pods = "-1"
+			},
+			want: []string{"FieldValueInvalid spec.resources.quota.count.pods"},
+		},
+		{
+			name: "requests greater than limits",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Requests.Memory = "2Gi"
+			},
+			want: []string{"FieldValueInvalid spec.resources.requests.memory"},
+		},
+		{
+			name: "limits greater than max",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Limits.Cpu = "1"
+			},
+			want: []string{"FieldValueInvalid spec.resources.limits.cpu"},
+		},
+		{
+			name: "max greater than quota limits",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Max.EphemeralStorage = "20Gi"
+			},
+			want: []string{"FieldValueInvalid spec.resources.max.ephemeralStorage"},
+		},
+		{
+			name: "quota requests greater than quota limits",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Quota.Requests.Cpu = "3"
+			},
+			want: []string{"FieldValueInvalid spec.resources.quota.requests.cpu"},
+		},
+		{
+			name: "min greater than requests",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Min.Cpu = "200m"
+			},
+			want: []string{"FieldValueInvalid spec.resources.min.cpu"},
+		},
+		{
+			name: "equal quantities in different units",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Requests.Cpu = "0.5"
+				component.Spec.Resources.Limits.Cpu = "500m"
+			},
+			want: nil,
+		},
+		{
+			name: "ratio less than one",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.MaxLimitRequestRatio.Cpu = "0.5"
+			},
+			want: []string{"FieldValueInvalid spec.resources.maxLimitRequestRatio.cpu"},
+		},
+		{
+			name: "hard quota set by a typed field",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Quota.Count.Pods = "10"
+				component.Spec.Resources.Quota.Hard = map[string]string{"pods": "20", "limits.cpu": "1"}
+			},
+			want: []string{
+				"FieldValueForbidden spec.resources.quota.hard[limits.cpu]",
+				"FieldValueForbidden spec.resources.quota.hard[pods]",
+			},
+		},
+		{
+			name: "extended resource without a domain prefix",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.Quota.ExtendedResources = map[string]string{"gpu": "1"}
+			},
+			want: []string{"FieldValueInvalid spec.resources.quota.extendedResources[gpu]"},
+		},
+		{
+			name: "valid scoped quota",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.ScopedQuotas = []TanzuNamespaceSpecResourcesScopedQuota{
+					{
+						Name: "high",
+						Hard: map[string]string{"pods": "10"},
+						ScopeSelector: &corev1.ScopeSelector{
+							MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+								{
+									ScopeName: corev1.ResourceQuotaScopePriorityClass,
+									Operator:  corev1.ScopeSelectorOpIn,
+									Values:    []string{"high"},
+								},
+							},
+						},
+					},
+				}
+			},
+			want: nil,
+		},
+		{
+			name: "invalid scoped quotas",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.Resources.ScopedQuotas = []TanzuNamespaceSpecResourcesScopedQuota{
+					{
+						Name:   "terminating",
+						Hard:   map[string]string{"pods": "ten"},
+						Scopes: []corev1.ResourceQuotaScope{"Unknown"},
+					},
+					{
+						Name: "terminating",
+						ScopeSelector: &corev1.ScopeSelector{
+							MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+								{
+									ScopeName: corev1.ResourceQuotaScopeBestEffort,
+									Operator:  corev1.ScopeSelectorOpIn,
+								},
+							},
+						},
+					},
+					{
+						Name: "unscoped",
+						Hard: map[string]string{"pods": "10"},
+					},
+				}
+			},
+			want: []string{
+				"FieldValueDuplicate spec.resources.scopedQuotas[1].name",
+				"FieldValueInvalid spec.resources.scopedQuotas[0].hard[pods]",
+				"FieldValueInvalid spec.resources.scopedQuotas[1].scopeSelector.matchExpressions[0].operator",
+				"FieldValueNotSupported spec.resources.scopedQuotas[0].scopes[0]",
+				"FieldValueRequired spec.resources.scopedQuotas[1].hard",
+				"FieldValueRequired spec.resources.scopedQuotas[1].scopeSelector.matchExpressions[0].values",
+				"FieldValueRequired spec.resources.scopedQuotas[2]",
+			},
+		},
+		{
+			name: "reserved metadata keys",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.NamespaceMetadata.Labels = map[string]string{
+					"team":                                   "platform",
+					"kubernetes.io/metadata.name":            "tenant",
+					"pod-security.kubernetes.io/enforce":     "restricted",
+					"tenancy.platform.cnr.vmware.com/tenant": "tenant",
+				}
+				component.Spec.NamespaceMetadata.Annotations = map[string]string{
+					"k8s.io/owner": "platform",
+				}
+			},
+			want: []string{
+				"FieldValueForbidden spec.namespaceMetadata.annotations[k8s.io/owner]",
+				"FieldValueForbidden spec.namespaceMetadata.labels[kubernetes.io/metadata.name]",
+				"FieldValueForbidden spec.namespaceMetadata.labels[pod-security.kubernetes.io/enforce]",
+				"FieldValueForbidden spec.namespaceMetadata.labels[tenancy.platform.cnr.vmware.com/tenant]",
+			},
+		},
+		{
+			name: "pod security metadata keys",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.NamespaceMetadata.Labels = map[string]string{
+					"pod-security.kubernetes.io/enforce":         "privileged",
+					"pod-security.kubernetes.io/enforce-version": "latest",
+				}
+				component.Spec.NamespaceMetadata.Annotations = map[string]string{
+					"pod-security.kubernetes.io/audit": "privileged",
+				}
+			},
+			want: []string{
+				"FieldValueForbidden spec.namespaceMetadata.annotations[pod-security.kubernetes.io/audit]",
+				"FieldValueForbidden spec.namespaceMetadata.labels[pod-security.kubernetes.io/enforce-version]",
+				"FieldValueForbidden spec.namespaceMetadata.labels[pod-security.kubernetes.io/enforce]",
+			},
+		},
+		{
+			name: "invalid label value",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.NamespaceMetadata.Labels = map[string]string{"team": "platform team"}
+			},
+			want: []string{"FieldValueInvalid spec.namespaceMetadata.labels[team]"},
+		},
+		{
+			name: "invalid rbac bindings",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.RBAC.Bindings = []TanzuNamespaceSpecRBACBinding{
+					{Name: "admins", Role: TanzuNamespaceRoleAdmin, ClusterRole: "admin"},
+					{Name: "admins"},
+					{
+						Name:        "deployers",
+						ClusterRole: "edit",
+						ServiceAccounts: []TanzuNamespaceSpecRBACServiceAccount{
+							{Name: "deployer", Create: true},
+							{Name: "deployer", Namespace: "tenant", Create: true},
+							{Name: "deployer", Namespace: "ci"},
+							{Name: "deployer", Namespace: "ci", Create: true},
+						},
+					},
+				}
+			},
+			want: []string{
+				"FieldValueDuplicate spec.rbac.bindings[1].name",
+				"FieldValueInvalid spec.rbac.bindings[0].clusterRole",
+				"FieldValueInvalid spec.rbac.bindings[2].serviceAccounts[3].namespace",
+				"FieldValueRequired spec.rbac.bindings[1]",
+			},
+		},
+		{
+			name: "cluster role which is not allowed",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.RBAC.Bindings = []TanzuNamespaceSpecRBACBinding{
+					{Name: "viewers", ClusterRole: "view"},
+					{Name: "operators", ClusterRole: "cluster-admin"},
+				}
+			},
+			want: []string{"FieldValueNotSupported spec.rbac.bindings[1].clusterRole"},
+		},
+		{
+			name: "invalid network policies",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.NetworkPolicies = []TanzuNamespaceSpecNetworkPolicy{
+					{
+						Name: "allow",
+						Ingress: []TanzuNamespaceSpecNetworkPolicyRule{
+							{
+								Peers: []TanzuNamespaceSpecNetworkPolicyPeer{
+									{NamespaceLabels: map[string]string{}},
+									{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+									{CIDR: "10.0.0.0/8", PodLabels: map[string]string{"app": "web"}},
+									{Except: []string{"10.1.0.0/16"}},
+								},
+							},
+						},
+					},
+					{
+						Name: "allow",
+						Egress: []TanzuNamespaceSpecNetworkPolicyRule{
+							{
+								Peers: []TanzuNamespaceSpecNetworkPolicyPeer{
+									{CIDR: "10.0.0.0", Except: []string{"10.1.0.0/33"}},
+								},
+							},
+						},
+					},
+				}
+			},
+			want: []string{
+				"FieldValueDuplicate spec.networkPolicies[1].name",
+				"FieldValueForbidden spec.networkPolicies[0].ingress[0].peers[3].except",
+				"FieldValueInvalid spec.networkPolicies[0].ingress[0].peers[2].cidr",
+				"FieldValueInvalid spec.networkPolicies[1].egress[0].peers[0].cidr",
+				"FieldValueInvalid spec.networkPolicies[1].egress[0].peers[0].except[0]",
+				"FieldValueRequired spec.networkPolicies[0].ingress[0].peers[3]",
+			},
+		},
+		{
+			name: "duplicate image pull secrets",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.ImagePullSecrets = []TanzuNamespaceSpecImagePullSecret{
+					{Name: "registry"},
+					{Name: "registry"},
+				}
+			},
+			want: []string{"FieldValueDuplicate spec.imagePullSecrets[1].name"},
+		},
+		{
+			name: "invalid pod security",
+			mutate: func(component *TanzuNamespace) {
+				component.Spec.PodSecurity = &TanzuNamespaceSpecPodSecurity{
+					Enforce:        "strict",
+					EnforceVersion: "1.25",
+				}
+			},
+			want: []string{
+				"FieldValueInvalid spec.podSecurity.enforceVersion",
+				"FieldValueNotSupported spec.podSecurity.enforce",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			component := validTanzuNamespace()
+			tt.mutate(component)
+
+			if got := errorFields(component.Validate()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTanzuNamespace_ValidateOrder(t *testing.T) {
+	t.Parallel()
+
+	component := validTanzuNamespace()
+	component.Spec.Resources.MaxLimitRequestRatio = TanzuNamespaceSpecResourcesMaxLimitRequestRatio{Cpu: "0.5", Memory: "0.5"}
+	component.Spec.Resources.Quota.Hard = map[string]string{"pods": "ten", "services": "ten", "secrets": "ten"}
+	component.Spec.PodSecurity = &TanzuNamespaceSpecPodSecurity{Enforce: "strict", Audit: "strict", Warn: "strict"}
+	component.Spec.NamespaceMetadata.Labels = map[string]string{"b/": "value", "a/": "value", "c/": "value"}
+
+	want := component.Validate().ToAggregate().Error()
+
+	for i := 0; i < 10; i++ {
+		if got := component.Validate().ToAggregate().Error(); got != want {
+			t.Fatalf("Validate() = %s, want %s", got, want)
+		}
+	}
+}
+
+// validTanzuNamespace returns a TanzuNamespace which passes validation.
+func validTanzuNamespace() *TanzuNamespace {
+	component := &TanzuNamespace{}
+	component.Spec.Namespace = "tenant"
+
+	specResources := &component.Spec.Resources
+	specResources.Requests = TanzuNamespaceSpecResourcesRequests{Cpu: "100m", Memory: "64Mi", EphemeralStorage: "1Gi"}
+	specResources.Limits = TanzuNamespaceSpecResourcesLimits{Cpu: "125m", Memory: "128Mi", EphemeralStorage: "2Gi"}
+	specResources.Max = TanzuNamespaceSpecResourcesMax{Cpu: "500m", Memory: "512Mi", EphemeralStorage: "4Gi"}
+	specResources.Quota.Requests = TanzuNamespaceSpecResourcesQuotaRequests{Cpu: "2", Memory: "4Gi", EphemeralStorage: "10Gi"}
+	specResources.Quota.Limits = TanzuNamespaceSpecResourcesQuotaLimits{Cpu: "2", Memory: "4Gi", EphemeralStorage: "10Gi"}
+
+	return component
+}
+
+// errorFields returns the sorted types and fields of a list of errors.
+func errorFields(errs field.ErrorList) []string {
+	var fields []string
+
+	for _, err := range errs {
+		fields = append(fields, string(err.Type)+" "+err.Field)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
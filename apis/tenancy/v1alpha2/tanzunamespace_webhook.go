@@ -0,0 +1,118 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var tanzunamespacelog = logf.Log.WithName("tanzunamespace-resource")
+
+// tanzunamespaceReader is used to read the existing TanzuNamespace objects from the cluster when
+// validating that a namespace is only claimed by a single TanzuNamespace.  It reads directly from
+// the API server, as the cache may not yet contain a TanzuNamespace which was just created.
+var tanzunamespaceReader client.Reader
+
+// SetupWebhookWithManager registers the webhooks for the TanzuNamespace with the manager.
+func (component *TanzuNamespace) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	tanzunamespaceReader = mgr.GetAPIReader()
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(component).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-tenancy-platform-cnr-vmware-com-v1alpha2-tanzunamespace,mutating=false,failurePolicy=fail,sideEffects=None,groups=tenancy.platform.cnr.vmware.com,resources=tanzunamespaces,verbs=create;update,versions=v1alpha2,name=vtanzunamespace.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &TanzuNamespace{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (component *TanzuNamespace) ValidateCreate() error {
+	tanzunamespacelog.V(4).Info("validate create", "name", component.Name)
+
+	errs := component.Validate()
+	errs = append(errs, component.validateUniqueNamespace()...)
+
+	return component.toInvalidError(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.  The
+// spec is only validated when it changes and the TanzuNamespace is not being deleted, so that
+// metadata, such as finalizers and the suspend annotation, may always be updated, even for objects
+// which were created before a validation was introduced.
+func (component *TanzuNamespace) ValidateUpdate(old runtime.Object) error {
+	tanzunamespacelog.V(4).Info("validate update", "name", component.Name)
+
+	oldComponent, ok := old.(*TanzuNamespace)
+	if !ok {
+		return fmt.Errorf("expected object of kind TanzuNamespace; found %T", old)
+	}
+
+	var errs field.ErrorList
+
+	if component.DeletionTimestamp == nil && !equality.Semantic.DeepEqual(oldComponent.Spec, component.Spec) {
+		errs = component.Validate()
+	}
+
+	if oldComponent.Spec.Namespace != component.Spec.Namespace {
+		errs = append(errs, field.Forbidden(
+			field.NewPath("spec", "namespace"),
+			fmt.Sprintf("field is immutable; namespace was previously set to %s", oldComponent.Spec.Namespace),
+		))
+	}
+
+	return component.toInvalidError(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (component *TanzuNamespace) ValidateDelete() error {
+	return nil
+}
+
+// validateUniqueNamespace returns an error if the namespace of the TanzuNamespace has already been
+// claimed by another TanzuNamespace.
+func (component *TanzuNamespace) validateUniqueNamespace() field.ErrorList {
+	if tanzunamespaceReader == nil || component.Spec.Namespace == "" {
+		return nil
+	}
+
+	components := &TanzuNamespaceList{}
+	if err := tanzunamespaceReader.List(context.Background(), components); err != nil {
+		return field.ErrorList{field.InternalError(
+			field.NewPath("spec", "namespace"),
+			fmt.Errorf("unable to list existing TanzuNamespaces; %w", err),
+		)}
+	}
+
+	for _, existing := range components.Items {
+		if existing.Name != component.Name && existing.Spec.Namespace == component.Spec.Namespace {
+			return field.ErrorList{field.Duplicate(
+				field.NewPath("spec", "namespace"),
+				fmt.Sprintf("%s (already claimed by TanzuNamespace %s)", component.Spec.Namespace, existing.Name),
+			)}
+		}
+	}
+
+	return nil
+}
+
+// toInvalidError converts a list of errors into an invalid error which is returned to the client.
+func (component *TanzuNamespace) toInvalidError(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(component.GetComponentGVK().GroupKind(), component.Name, errs)
+}
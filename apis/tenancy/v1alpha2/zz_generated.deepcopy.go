@@ -10,7 +10,9 @@ package v1alpha2
 
 import (
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -18,7 +20,7 @@ func (in *TanzuNamespace) DeepCopyInto(out *TanzuNamespace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -75,7 +77,26 @@ func (in *TanzuNamespaceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceSpec) DeepCopyInto(out *TanzuNamespaceSpec) {
 	*out = *in
-	out.Resources = in.Resources
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.RBAC.DeepCopyInto(&out.RBAC)
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]TanzuNamespaceSpecNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]TanzuNamespaceSpecImagePullSecret, len(*in))
+		copy(*out, *in)
+	}
+	in.NamespaceMetadata.DeepCopyInto(&out.NamespaceMetadata)
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(TanzuNamespaceSpecPodSecurity)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpec.
@@ -88,13 +109,262 @@ func (in *TanzuNamespaceSpec) DeepCopy() *TanzuNamespaceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecImagePullSecret) DeepCopyInto(out *TanzuNamespaceSpecImagePullSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecImagePullSecret.
+func (in *TanzuNamespaceSpecImagePullSecret) DeepCopy() *TanzuNamespaceSpecImagePullSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecImagePullSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecNamespaceMetadata) DeepCopyInto(out *TanzuNamespaceSpecNamespaceMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecNamespaceMetadata.
+func (in *TanzuNamespaceSpecNamespaceMetadata) DeepCopy() *TanzuNamespaceSpecNamespaceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecNamespaceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecNetworkPolicy) DeepCopyInto(out *TanzuNamespaceSpecNetworkPolicy) {
+	*out = *in
+	if in.TargetPodLabels != nil {
+		in, out := &in.TargetPodLabels, &out.TargetPodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]TanzuNamespaceSpecNetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]TanzuNamespaceSpecNetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecNetworkPolicy.
+func (in *TanzuNamespaceSpecNetworkPolicy) DeepCopy() *TanzuNamespaceSpecNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecNetworkPolicyPeer) DeepCopyInto(out *TanzuNamespaceSpecNetworkPolicyPeer) {
+	*out = *in
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecNetworkPolicyPeer.
+func (in *TanzuNamespaceSpecNetworkPolicyPeer) DeepCopy() *TanzuNamespaceSpecNetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecNetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecNetworkPolicyPort) DeepCopyInto(out *TanzuNamespaceSpecNetworkPolicyPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecNetworkPolicyPort.
+func (in *TanzuNamespaceSpecNetworkPolicyPort) DeepCopy() *TanzuNamespaceSpecNetworkPolicyPort {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecNetworkPolicyPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecNetworkPolicyRule) DeepCopyInto(out *TanzuNamespaceSpecNetworkPolicyRule) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]TanzuNamespaceSpecNetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]TanzuNamespaceSpecNetworkPolicyPort, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecNetworkPolicyRule.
+func (in *TanzuNamespaceSpecNetworkPolicyRule) DeepCopy() *TanzuNamespaceSpecNetworkPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecNetworkPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecPodSecurity) DeepCopyInto(out *TanzuNamespaceSpecPodSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecPodSecurity.
+func (in *TanzuNamespaceSpecPodSecurity) DeepCopy() *TanzuNamespaceSpecPodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecPodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecRBAC) DeepCopyInto(out *TanzuNamespaceSpecRBAC) {
+	*out = *in
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]TanzuNamespaceSpecRBACBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecRBAC.
+func (in *TanzuNamespaceSpecRBAC) DeepCopy() *TanzuNamespaceSpecRBAC {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecRBAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecRBACBinding) DeepCopyInto(out *TanzuNamespaceSpecRBACBinding) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]TanzuNamespaceSpecRBACServiceAccount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecRBACBinding.
+func (in *TanzuNamespaceSpecRBACBinding) DeepCopy() *TanzuNamespaceSpecRBACBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecRBACBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecRBACServiceAccount) DeepCopyInto(out *TanzuNamespaceSpecRBACServiceAccount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecRBACServiceAccount.
+func (in *TanzuNamespaceSpecRBACServiceAccount) DeepCopy() *TanzuNamespaceSpecRBACServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecRBACServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceSpecResources) DeepCopyInto(out *TanzuNamespaceSpecResources) {
 	*out = *in
 	out.Limits = in.Limits
 	out.Requests = in.Requests
 	out.Max = in.Max
-	out.Quota = in.Quota
+	out.Min = in.Min
+	out.MaxLimitRequestRatio = in.MaxLimitRequestRatio
+	out.Pod = in.Pod
+	out.PersistentVolumeClaim = in.PersistentVolumeClaim
+	in.Quota.DeepCopyInto(&out.Quota)
+	if in.ScopedQuotas != nil {
+		in, out := &in.ScopedQuotas, &out.ScopedQuotas
+		*out = make([]TanzuNamespaceSpecResourcesScopedQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResources.
@@ -137,11 +407,108 @@ func (in *TanzuNamespaceSpecResourcesMax) DeepCopy() *TanzuNamespaceSpecResource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesMaxLimitRequestRatio) DeepCopyInto(out *TanzuNamespaceSpecResourcesMaxLimitRequestRatio) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesMaxLimitRequestRatio.
+func (in *TanzuNamespaceSpecResourcesMaxLimitRequestRatio) DeepCopy() *TanzuNamespaceSpecResourcesMaxLimitRequestRatio {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesMaxLimitRequestRatio)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesMin) DeepCopyInto(out *TanzuNamespaceSpecResourcesMin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesMin.
+func (in *TanzuNamespaceSpecResourcesMin) DeepCopy() *TanzuNamespaceSpecResourcesMin {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesMin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesPersistentVolumeClaim) DeepCopyInto(out *TanzuNamespaceSpecResourcesPersistentVolumeClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesPersistentVolumeClaim.
+func (in *TanzuNamespaceSpecResourcesPersistentVolumeClaim) DeepCopy() *TanzuNamespaceSpecResourcesPersistentVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesPersistentVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesPod) DeepCopyInto(out *TanzuNamespaceSpecResourcesPod) {
+	*out = *in
+	out.Min = in.Min
+	out.Max = in.Max
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesPod.
+func (in *TanzuNamespaceSpecResourcesPod) DeepCopy() *TanzuNamespaceSpecResourcesPod {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesPodResources) DeepCopyInto(out *TanzuNamespaceSpecResourcesPodResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesPodResources.
+func (in *TanzuNamespaceSpecResourcesPodResources) DeepCopy() *TanzuNamespaceSpecResourcesPodResources {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesPodResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceSpecResourcesQuota) DeepCopyInto(out *TanzuNamespaceSpecResourcesQuota) {
 	*out = *in
 	out.Requests = in.Requests
 	out.Limits = in.Limits
+	out.Count = in.Count
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]TanzuNamespaceSpecResourcesQuotaStorageClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtendedResources != nil {
+		in, out := &in.ExtendedResources, &out.ExtendedResources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesQuota.
@@ -154,6 +521,21 @@ func (in *TanzuNamespaceSpecResourcesQuota) DeepCopy() *TanzuNamespaceSpecResour
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesQuotaCount) DeepCopyInto(out *TanzuNamespaceSpecResourcesQuotaCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesQuotaCount.
+func (in *TanzuNamespaceSpecResourcesQuotaCount) DeepCopy() *TanzuNamespaceSpecResourcesQuotaCount {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesQuotaCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceSpecResourcesQuotaLimits) DeepCopyInto(out *TanzuNamespaceSpecResourcesQuotaLimits) {
 	*out = *in
@@ -184,6 +566,21 @@ func (in *TanzuNamespaceSpecResourcesQuotaRequests) DeepCopy() *TanzuNamespaceSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesQuotaStorageClass) DeepCopyInto(out *TanzuNamespaceSpecResourcesQuotaStorageClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesQuotaStorageClass.
+func (in *TanzuNamespaceSpecResourcesQuotaStorageClass) DeepCopy() *TanzuNamespaceSpecResourcesQuotaStorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesQuotaStorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceSpecResourcesRequests) DeepCopyInto(out *TanzuNamespaceSpecResourcesRequests) {
 	*out = *in
@@ -199,6 +596,38 @@ func (in *TanzuNamespaceSpecResourcesRequests) DeepCopy() *TanzuNamespaceSpecRes
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceSpecResourcesScopedQuota) DeepCopyInto(out *TanzuNamespaceSpecResourcesScopedQuota) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]v1.ResourceQuotaScope, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScopeSelector != nil {
+		in, out := &in.ScopeSelector, &out.ScopeSelector
+		*out = new(v1.ScopeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceSpecResourcesScopedQuota.
+func (in *TanzuNamespaceSpecResourcesScopedQuota) DeepCopy() *TanzuNamespaceSpecResourcesScopedQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceSpecResourcesScopedQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TanzuNamespaceStatus) DeepCopyInto(out *TanzuNamespaceStatus) {
 	*out = *in
@@ -207,6 +636,25 @@ func (in *TanzuNamespaceStatus) DeepCopyInto(out *TanzuNamespaceStatus) {
 		*out = make([]common.PhaseCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.StatusConditions != nil {
+		in, out := &in.StatusConditions, &out.StatusConditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceQuotas != nil {
+		in, out := &in.ResourceQuotas, &out.ResourceQuotas
+		*out = make([]TanzuNamespaceStatusResourceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QuotaUsage != nil {
+		in, out := &in.QuotaUsage, &out.QuotaUsage
+		*out = new(TanzuNamespaceStatusQuotaUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = make([]common.Resource, len(*in))
@@ -223,3 +671,81 @@ func (in *TanzuNamespaceStatus) DeepCopy() *TanzuNamespaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceStatusQuotaUsage) DeepCopyInto(out *TanzuNamespaceStatusQuotaUsage) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TanzuNamespaceStatusQuotaUsageResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceStatusQuotaUsage.
+func (in *TanzuNamespaceStatusQuotaUsage) DeepCopy() *TanzuNamespaceStatusQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceStatusQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceStatusQuotaUsageResource) DeepCopyInto(out *TanzuNamespaceStatusQuotaUsageResource) {
+	*out = *in
+	out.Used = in.Used.DeepCopy()
+	out.Hard = in.Hard.DeepCopy()
+	if in.Utilization != nil {
+		in, out := &in.Utilization, &out.Utilization
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceStatusQuotaUsageResource.
+func (in *TanzuNamespaceStatusQuotaUsageResource) DeepCopy() *TanzuNamespaceStatusQuotaUsageResource {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceStatusQuotaUsageResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TanzuNamespaceStatusResourceQuota) DeepCopyInto(out *TanzuNamespaceStatusResourceQuota) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Exhausted != nil {
+		in, out := &in.Exhausted, &out.Exhausted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TanzuNamespaceStatusResourceQuota.
+func (in *TanzuNamespaceStatusResourceQuota) DeepCopy() *TanzuNamespaceStatusResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TanzuNamespaceStatusResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
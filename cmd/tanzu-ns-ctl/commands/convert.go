@@ -0,0 +1,281 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	tenancyv1alpha1 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha1"
+	v1alpha1resources "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha1/tanzunamespace"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+type convertCommand struct {
+	*cobra.Command
+	workloadManifests []string
+	toVersion         string
+	inPlace           bool
+}
+
+// newConvertCommand creates a new instance of the convert subcommand.
+func (c *TanzuNsCtlCommand) newConvertCommand() {
+	cv := &convertCommand{}
+	convertCmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert a workload's deprecated custom resource manifests to a newer version",
+		Long: "Convert a workload's deprecated custom resource manifests to a newer version.  Documents which " +
+			"are not of the deprecated version are written unchanged.",
+		RunE: cv.convert,
+	}
+
+	convertCmd.Flags().StringArrayVarP(
+		&cv.workloadManifests,
+		"workload-manifest",
+		"w",
+		nil,
+		"Filepath to a workload manifest, a directory of workload manifests or - for standard input.  "+
+			"May be specified multiple times.",
+	)
+	convertCmd.MarkFlagRequired("workload-manifest")
+
+	convertCmd.Flags().StringVar(
+		&cv.toVersion,
+		"to",
+		tenancyv1alpha2.GroupVersion.Version,
+		"Version to convert the workload manifests to.",
+	)
+
+	convertCmd.Flags().BoolVarP(
+		&cv.inPlace,
+		"in-place",
+		"i",
+		false,
+		"Rewrite the workload manifest files which contain deprecated documents rather than writing to standard out.",
+	)
+
+	c.AddCommand(convertCmd)
+}
+
+// convert converts each of the deprecated documents of the workload manifests.
+func (cv *convertCommand) convert(cmd *cobra.Command, args []string) error {
+	if cv.toVersion != tenancyv1alpha2.GroupVersion.Version {
+		return fmt.Errorf("unsupported version %s; workload manifests may only be converted to %s",
+			cv.toVersion, tenancyv1alpha2.GroupVersion.Version)
+	}
+
+	for _, path := range cv.workloadManifests {
+		if cv.inPlace && path == stdinManifest {
+			return fmt.Errorf("workload manifests which are read from standard input may not be converted in place")
+		}
+	}
+
+	documents, err := readManifests(cv.workloadManifests, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	// the converted content of each source, in order of the sources, and whether any of the
+	// documents of the source were converted
+	var sources []string
+
+	contents := map[string]*bytes.Buffer{}
+	converted := map[string]bool{}
+
+	for _, document := range documents {
+		content, warnings, wasConverted, err := convertDocument(document)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s, %w", document, err)
+		}
+
+		for _, warning := range warnings {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: warning: %s\n", document, warning)
+		}
+
+		if _, found := contents[document.source]; !found {
+			sources = append(sources, document.source)
+			contents[document.source] = &bytes.Buffer{}
+		}
+
+		contents[document.source].WriteString("---\n")
+		contents[document.source].Write(content)
+
+		converted[document.source] = converted[document.source] || wasConverted
+	}
+
+	for _, source := range sources {
+		if !cv.inPlace {
+			if _, err := io.Copy(cmd.OutOrStdout(), contents[source]); err != nil {
+				return fmt.Errorf("failed to write output, %w", err)
+			}
+
+			continue
+		}
+
+		if !converted[source] {
+			continue
+		}
+
+		if err := rewriteFile(source, contents[source].Bytes()); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "converted %s\n", source)
+	}
+
+	return nil
+}
+
+// convertDocument converts a document of the deprecated v1alpha1 version of the workload to
+// v1alpha2, returning the content of the converted document along with warnings for the fields
+// which have no v1alpha2 equivalent.  The default values of v1alpha1 are set for the fields which
+// are empty.  Other documents are returned unchanged.
+func convertDocument(document *manifestDocument) (content []byte, warnings []string, converted bool, err error) {
+	deprecated, err := isDeprecatedWorkload(document)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if !deprecated {
+		content = document.content
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			content = append(content, '\n')
+		}
+
+		return content, nil, false, nil
+	}
+
+	var deprecatedWorkload tenancyv1alpha1.TanzuNamespace
+	if err := yaml.Unmarshal(document.content, &deprecatedWorkload); err != nil {
+		return nil, nil, false, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	warnings = conversionWarnings(&deprecatedWorkload.Spec)
+
+	deprecatedWorkload.Spec = v1alpha1resources.DefaultSpec(&deprecatedWorkload)
+
+	workload := &tenancyv1alpha2.TanzuNamespace{}
+	if err := deprecatedWorkload.ConvertTo(workload); err != nil {
+		return nil, nil, false, fmt.Errorf("failed to convert workload to %s, %w", tenancyv1alpha2.GroupVersion, err)
+	}
+
+	workload.SetGroupVersionKind(workload.GetComponentGVK())
+
+	// the annotation which preserves the v1alpha1 spec is only needed to convert the workload back
+	// to v1alpha1 within the cluster
+	delete(workload.Annotations, tenancyv1alpha1.V1alpha1SpecAnnotation)
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	unstructured.RemoveNestedField(object, "status")
+	unstructured.RemoveNestedField(object, "metadata", "creationTimestamp")
+	removeEmptyFields(object)
+
+	content, err = yaml.Marshal(object)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to marshal workload, %w", err)
+	}
+
+	return content, warnings, true, nil
+}
+
+// conversionWarnings returns a warning for each of the fields of a v1alpha1 spec which have no
+// v1alpha2 equivalent and are therefore dropped by the conversion.
+func conversionWarnings(spec *tenancyv1alpha1.TanzuNamespaceSpec) []string {
+	var warnings []string
+
+	for i, rbac := range spec.RBAC {
+		path := fmt.Sprintf("spec.rbac[%d]", i)
+
+		switch rbac.Type {
+		case "namespace-admin", "developer", "read-only":
+		default:
+			warnings = append(warnings, fmt.Sprintf("%s.type: type %q has no v1alpha2 equivalent and is dropped", path, rbac.Type))
+
+			continue
+		}
+
+		if !rbac.Create {
+			continue
+		}
+
+		if rbac.Role != "" {
+			warnings = append(warnings, fmt.Sprintf("%s.role: custom role names have no v1alpha2 equivalent; "+
+				"the built-in role of the type is used", path))
+		}
+
+		if rbac.RoleBinding != "" {
+			warnings = append(warnings, fmt.Sprintf("%s.roleBinding: custom role binding names have no v1alpha2 "+
+				"equivalent; the role binding is named after the type", path))
+		}
+
+		if rbac.Permissions != "" {
+			warnings = append(warnings, fmt.Sprintf("%s.permissions: custom permissions have no v1alpha2 equivalent; "+
+				"the permissions of the built-in role of the type are used", path))
+		}
+
+		if rbac.Namespace != "" {
+			warnings = append(warnings, fmt.Sprintf("%s.namespace: the namespace of an rbac entry has no v1alpha2 "+
+				"equivalent; the service account is created in spec.namespace", path))
+		}
+	}
+
+	return warnings
+}
+
+// removeEmptyFields recursively removes the fields of an object which are nil or empty, so that
+// the fields of a converted workload which were not set are not written.
+func removeEmptyFields(object map[string]interface{}) {
+	for key, value := range object {
+		switch typedValue := value.(type) {
+		case nil:
+			delete(object, key)
+		case string:
+			if typedValue == "" {
+				delete(object, key)
+			}
+		case map[string]interface{}:
+			removeEmptyFields(typedValue)
+
+			if len(typedValue) == 0 {
+				delete(object, key)
+			}
+		case []interface{}:
+			for _, item := range typedValue {
+				if itemObject, ok := item.(map[string]interface{}); ok {
+					removeEmptyFields(itemObject)
+				}
+			}
+
+			if len(typedValue) == 0 {
+				delete(object, key)
+			}
+		}
+	}
+}
+
+// rewriteFile replaces the content of a file, preserving its permissions.
+func rewriteFile(filename string, content []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s, %w", filename, err)
+	}
+
+	if err := ioutil.WriteFile(filename, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file %s, %w", filename, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertDocument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		content       string
+		want          string
+		wantWarnings  []string
+		wantConverted bool
+		wantErr       bool
+	}{
+		{
+			name:    "v1alpha2 document is unchanged",
+			content: "apiVersion: tenancy.platform.cnr.vmware.com/v1alpha2\nkind: TanzuNamespace\n# tenant\nmetadata:\n  name: tenant",
+			want:    "apiVersion: tenancy.platform.cnr.vmware.com/v1alpha2\nkind: TanzuNamespace\n# tenant\nmetadata:\n  name: tenant\n",
+		},
+		{
+			name:    "other document is unchanged",
+			content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: tenant\n",
+			want:    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: tenant\n",
+		},
+		{
+			name: "v1alpha1 document with defaults",
+			content: `apiVersion: tenancy.platform.cnr.vmware.com/v1alpha1
+kind: TanzuNamespace
+metadata:
+  name: tenant
+spec:
+  limitRange:
+    defaultCPULimit: 250m
+  tanzuResourceQuotaMemoryLimits: 8Gi
+`,
+			want: `apiVersion: tenancy.platform.cnr.vmware.com/v1alpha2
+kind: TanzuNamespace
+metadata:
+  name: tenant
+spec:
+  namespace: tenant
+  resources:
+    limits:
+      cpu: 250m
+      memory: 64Mi
+    max:
+      cpu: 1000m
+      memory: 2Gi
+    quota:
+      limits:
+        cpu: 2000m
+        memory: 8Gi
+      requests:
+        cpu: 2000m
+        memory: 4Gi
+    requests:
+      cpu: 125m
+      memory: 64Mi
+`,
+			wantConverted: true,
+		},
+		{
+			name: "v1alpha1 document with network policies and rbac",
+			content: `apiVersion: tenancy.platform.cnr.vmware.com/v1alpha1
+kind: TanzuNamespace
+metadata:
+  name: tenant
+  labels:
+    team: platform
+spec:
+  name: tenant-namespace
+  networkPolicies:
+  - targetPodLabels:
+      app: web
+    ingressTCPPorts:
+    - 443
+  rbac:
+  - type: developer
+    create: true
+    user: deployer
+    role: custom-role
+    roleBinding: custom-rolebinding
+  - type: auditor
+    create: true
+  - type: read-only
+    create: false
+    role: ignored-role
+`,
+			want: `apiVersion: tenancy.platform.cnr.vmware.com/v1alpha2
+kind: TanzuNamespace
+metadata:
+  labels:
+    team: platform
+  name: tenant
+spec:
+  namespace: tenant-namespace
+  networkPolicies:
+  - egress:
+    - {}
+    ingress:
+    - ports:
+      - port: 443
+        protocol: TCP
+    name: "0"
+    targetPodLabels:
+      app: web
+  rbac:
+    bindings:
+    - name: developer
+      role: developer
+      serviceAccounts:
+      - create: true
+        name: deployer
+  resources:
+    limits:
+      cpu: 125m
+      memory: 64Mi
+    max:
+      cpu: 1000m
+      memory: 2Gi
+    quota:
+      limits:
+        cpu: 2000m
+        memory: 4Gi
+      requests:
+        cpu: 2000m
+        memory: 4Gi
+    requests:
+      cpu: 125m
+      memory: 64Mi
+`,
+			wantWarnings: []string{
+				`spec.rbac[0].role: custom role names have no v1alpha2 equivalent; the built-in role of the type is used`,
+				`spec.rbac[0].roleBinding: custom role binding names have no v1alpha2 equivalent; the role binding is named after the type`,
+				`spec.rbac[1].type: type "auditor" has no v1alpha2 equivalent and is dropped`,
+			},
+			wantConverted: true,
+		},
+		{
+			name:    "invalid document",
+			content: "apiVersion: [",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, warnings, converted, err := convertDocument(&manifestDocument{source: "test", index: 1, content: []byte(tt.content)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if string(content) != tt.want {
+				t.Errorf("convertDocument() content = %s, want %s", content, tt.want)
+			}
+
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("convertDocument() warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+
+			if converted != tt.wantConverted {
+				t.Errorf("convertDocument() converted = %v, want %v", converted, tt.wantConverted)
+			}
+		})
+	}
+}
+
+func TestRemoveEmptyFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		object map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{
+			name: "empty values are removed",
+			object: map[string]interface{}{
+				"nil":    nil,
+				"string": "",
+				"map":    map[string]interface{}{},
+				"list":   []interface{}{},
+				"value":  "value",
+			},
+			want: map[string]interface{}{"value": "value"},
+		},
+		{
+			name: "false and zero values are kept",
+			object: map[string]interface{}{
+				"create": false,
+				"port":   int64(0),
+			},
+			want: map[string]interface{}{
+				"create": false,
+				"port":   int64(0),
+			},
+		},
+		{
+			name: "nested maps which become empty are removed",
+			object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"rbac":      map[string]interface{}{"bindings": nil},
+					"namespace": "tenant",
+				},
+				"status": map[string]interface{}{"created": ""},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{"namespace": "tenant"},
+			},
+		},
+		{
+			name: "maps within lists are cleaned but kept",
+			object: map[string]interface{}{
+				"egress": []interface{}{
+					map[string]interface{}{"peers": nil},
+					map[string]interface{}{"ports": []interface{}{int64(53)}, "sameNamespace": ""},
+				},
+			},
+			want: map[string]interface{}{
+				"egress": []interface{}{
+					map[string]interface{}{},
+					map[string]interface{}{"ports": []interface{}{int64(53)}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			removeEmptyFields(tt.object)
+
+			if !reflect.DeepEqual(tt.object, tt.want) {
+				t.Errorf("removeEmptyFields() = %v, want %v", tt.object, tt.want)
+			}
+		})
+	}
+}
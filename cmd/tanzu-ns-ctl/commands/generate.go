@@ -4,16 +4,14 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"io"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
-	"sigs.k8s.io/yaml"
 
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
@@ -21,7 +19,7 @@ import (
 
 type generateCommand struct {
 	*cobra.Command
-	workloadManifest string
+	workloadManifests []string
 }
 
 // newGenerateCommand creates a new instance of the generate subcommand.
@@ -34,64 +32,123 @@ func (c *TanzuNsCtlCommand) newGenerateCommand() {
 		RunE:  g.generate,
 	}
 
-	generateCmd.Flags().StringVarP(
-		&g.workloadManifest,
+	generateCmd.Flags().StringArrayVarP(
+		&g.workloadManifests,
 		"workload-manifest",
 		"w",
-		"",
-		"Filepath to the workload manifest to generate child resources for.",
+		nil,
+		"Filepath to the workload manifest to generate child resources for, a directory of workload manifests "+
+			"or - for standard input.  May be specified multiple times.",
 	)
 	generateCmd.MarkFlagRequired("workload-manifest")
 
 	c.AddCommand(generateCmd)
 }
 
-// generate creates child resource manifests from a workload's custom resource.
+// generate creates child resource manifests from each of the workload custom resources of the
+// workload manifests.  An error with one of the documents is reported without stopping the
+// generation of the child resources of the other documents.
 func (g *generateCommand) generate(cmd *cobra.Command, args []string) error {
+	documents, err := readManifests(g.workloadManifests, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
 
-	filename, _ := filepath.Abs(g.workloadManifest)
+	// the errors of each document are reported below, so the usage is not useful when generation
+	// fails and the returned error is only reported once by the root command
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
 
-	yamlFile, err := ioutil.ReadFile(filename)
-	if err != nil {
-		return fmt.Errorf("failed to open file %s, %w", filename, err)
+	failed := 0
+
+	for _, document := range documents {
+		if err := generateDocument(document, cmd.OutOrStdout()); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", document, err)
+
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to generate child resources for %d of %d documents", failed, len(documents))
 	}
 
-	var workload tenancyv1alpha2.TanzuNamespace
+	return nil
+}
 
-	err = yaml.Unmarshal(yamlFile, &workload)
+// generateDocument writes the child resource manifests of the workload of a document.  The child
+// resources are only written once all of them have been generated, so that a failed document
+// does not produce partial output.
+func generateDocument(document *manifestDocument, outputStream io.Writer) error {
+	workload, _, err := decodeWorkload(document)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal yaml %s into workload, %w", filename, err)
+		return err
 	}
 
-	err = validateWorkload(&workload)
+	resourceObjects, err := constructResources(workload)
 	if err != nil {
-		return fmt.Errorf("error validating yaml %s, %w", filename, err)
+		return err
+	}
+
+	e := json.NewYAMLSerializer(json.DefaultMetaFactory, nil, nil)
+
+	var output bytes.Buffer
+
+	for _, o := range resourceObjects {
+		output.WriteString("---\n")
+
+		if err := e.Encode(o.(runtime.Object), &output); err != nil {
+			return fmt.Errorf("failed to write output, %w", err)
+		}
 	}
 
+	if _, err := output.WriteTo(outputStream); err != nil {
+		return fmt.Errorf("failed to write output, %w", err)
+	}
+
+	return nil
+}
+
+// constructResources creates the child resources of a workload in memory.  The default
+// ServiceAccount is omitted when the workload has no image pull secrets, as the operator only
+// patches it to remove the image pull secrets which it previously managed.
+func constructResources(workload *tenancyv1alpha2.TanzuNamespace) ([]metav1.Object, error) {
 	resourceObjects := make([]metav1.Object, len(tanzunamespace.CreateFuncs))
 
 	for i, f := range tanzunamespace.CreateFuncs {
-		resource, err := f(&workload)
+		resource, err := f(workload)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		resourceObjects[i] = resource
 	}
 
-	e := json.NewYAMLSerializer(json.DefaultMetaFactory, nil, nil)
+	for _, f := range tanzunamespace.CreateArrayFuncs {
+		resourceArray, err := f(workload)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects = append(resourceObjects, resourceArray...)
+	}
 
-	outputStream := os.Stdout
+	tanzunamespace.SetNamespaceMetadata(workload, resourceObjects)
 
-	for _, o := range resourceObjects {
-		if _, err := outputStream.WriteString("---\n"); err != nil {
-			return fmt.Errorf("failed to write output, %w", err)
-		}
+	if len(workload.Spec.ImagePullSecrets) > 0 {
+		return resourceObjects, nil
+	}
 
-		if err := e.Encode(o.(runtime.Object), os.Stdout); err != nil {
-			return fmt.Errorf("failed to write output, %w", err)
+	constructed := []metav1.Object{}
+
+	for _, resourceObject := range resourceObjects {
+		if _, found := resourceObject.GetAnnotations()[tanzunamespace.ImagePullSecretsAnnotation]; found &&
+			tanzunamespace.IsSharedResource(resourceObject) {
+			continue
 		}
+
+		constructed = append(constructed, resourceObject)
 	}
 
-	return nil
+	return constructed, nil
 }
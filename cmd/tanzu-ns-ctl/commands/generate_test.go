@@ -0,0 +1,169 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+// testWorkload is a v1alpha2 workload manifest with a name and namespace of tenant.
+const testWorkload = `apiVersion: tenancy.platform.cnr.vmware.com/v1alpha2
+kind: TanzuNamespace
+metadata:
+  name: tenant
+spec:
+  namespace: tenant
+`
+
+// resourceKindsAndNames returns the kind and name of each of a list of resources.
+func resourceKindsAndNames(resourceObjects []metav1.Object) []string {
+	var kindsAndNames []string
+
+	for _, resourceObject := range resourceObjects {
+		kind := resourceObject.(runtime.Object).GetObjectKind().GroupVersionKind().Kind
+		kindsAndNames = append(kindsAndNames, kind+"/"+resourceObject.GetName())
+	}
+
+	return kindsAndNames
+}
+
+func TestConstructResources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		imagePullSecrets []tenancyv1alpha2.TanzuNamespaceSpecImagePullSecret
+		want             []string
+	}{
+		{
+			name: "default ServiceAccount is omitted without image pull secrets",
+			want: []string{
+				"Namespace/tenant",
+				"LimitRange/tanzu-limit-range",
+				"ResourceQuota/tanzu-resource-quota",
+				"NetworkPolicy/tanzu-network-policy",
+			},
+		},
+		{
+			name:             "default ServiceAccount is patched with image pull secrets",
+			imagePullSecrets: []tenancyv1alpha2.TanzuNamespaceSpecImagePullSecret{{Name: "registry"}},
+			want: []string{
+				"Namespace/tenant",
+				"LimitRange/tanzu-limit-range",
+				"ResourceQuota/tanzu-resource-quota",
+				"NetworkPolicy/tanzu-network-policy",
+				"Secret/registry",
+				"ServiceAccount/default",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			workload, _, err := decodeWorkload(&manifestDocument{source: "test", index: 1, content: []byte(testWorkload)})
+			if err != nil {
+				t.Fatalf("decodeWorkload() error = %v", err)
+			}
+
+			workload.Spec.ImagePullSecrets = tt.imagePullSecrets
+
+			resourceObjects, err := constructResources(workload)
+			if err != nil {
+				t.Fatalf("constructResources() error = %v", err)
+			}
+
+			if got := resourceKindsAndNames(resourceObjects); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("constructResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		stdin          string
+		wantNamespaces []string
+		wantStderr     string
+		wantErr        string
+	}{
+		{
+			name:           "multiple documents",
+			stdin:          testWorkload + "---\n" + strings.ReplaceAll(testWorkload, "tenant", "other"),
+			wantNamespaces: []string{"tenant", "other"},
+		},
+		{
+			name: "failed document does not stop the other documents",
+			stdin: testWorkload + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n---\n" +
+				strings.ReplaceAll(testWorkload, "tenant", "other"),
+			wantNamespaces: []string{"tenant", "other"},
+			wantStderr:     "<stdin> (document 2): error validating yaml",
+			wantErr:        "failed to generate child resources for 1 of 3 documents",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			c := NewTanzuNsCtlCommand()
+			c.SetArgs([]string{"generate", "-w", stdinManifest})
+			c.SetIn(strings.NewReader(tt.stdin))
+			c.SetOut(&stdout)
+			c.SetErr(&stderr)
+
+			err := c.Execute()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("generate error = %v", err)
+			}
+
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("generate error = %v, want %s", err, tt.wantErr)
+			}
+
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("generate stderr = %s, want %s", stderr.String(), tt.wantStderr)
+			}
+
+			documents, err := readManifestDocuments("stdout", &stdout)
+			if err != nil {
+				t.Fatalf("readManifestDocuments() error = %v", err)
+			}
+
+			var namespaces []string
+
+			for _, document := range documents {
+				var object metav1.PartialObjectMetadata
+				if err := yaml.Unmarshal(document.content, &object); err != nil {
+					t.Fatalf("failed to unmarshal %s, %v", document, err)
+				}
+
+				if object.Kind == "Namespace" {
+					namespaces = append(namespaces, object.Name)
+				}
+			}
+
+			if !reflect.DeepEqual(namespaces, tt.wantNamespaces) {
+				t.Errorf("generate namespaces = %v, want %v", namespaces, tt.wantNamespaces)
+			}
+		})
+	}
+}
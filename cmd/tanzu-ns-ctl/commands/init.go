@@ -25,7 +25,7 @@ spec:
       memory: "64Mi"
     max:
       cpu: "500m"
-      memory: "500m"
+      memory: "256Mi"
     quota:
       requests:
         cpu: "2000m"
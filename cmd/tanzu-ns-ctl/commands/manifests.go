@@ -0,0 +1,220 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	tenancyv1alpha1 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha1"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+// stdinManifest is the path of a workload manifest which is read from standard input.
+const stdinManifest = "-"
+
+// manifestExtensions are the extensions of the files which are read from a directory of workload
+// manifests.
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// manifestDocument is a single document of a workload manifest.
+type manifestDocument struct {
+	source  string
+	index   int
+	content []byte
+}
+
+// String returns the source and index of the document, which is used to identify the document
+// when reporting errors.
+func (document *manifestDocument) String() string {
+	return fmt.Sprintf("%s (document %d)", document.source, document.index)
+}
+
+// readManifests reads each of the documents of the workload manifests at the given paths.  A path
+// may be a file, a directory which is searched recursively for YAML and JSON files, or - for
+// standard input.  Documents which are empty are skipped.
+func readManifests(paths []string, stdin io.Reader) ([]*manifestDocument, error) {
+	var documents []*manifestDocument
+
+	for _, path := range paths {
+		if path == stdinManifest {
+			stdinDocuments, err := readManifestDocuments("<stdin>", stdin)
+			if err != nil {
+				return nil, err
+			}
+
+			documents = append(documents, stdinDocuments...)
+
+			continue
+		}
+
+		filenames, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, filename := range filenames {
+			fileDocuments, err := readManifestFile(filename)
+			if err != nil {
+				return nil, err
+			}
+
+			documents = append(documents, fileDocuments...)
+		}
+	}
+
+	return documents, nil
+}
+
+// manifestFiles returns the files of a path, which is either the file itself or the files of a
+// directory, searched recursively, which have a manifest extension.
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s, %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var filenames []string
+
+	if err := filepath.Walk(path, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		for _, extension := range manifestExtensions {
+			if strings.EqualFold(filepath.Ext(filename), extension) {
+				filenames = append(filenames, filename)
+
+				break
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read directory %s, %w", path, err)
+	}
+
+	return filenames, nil
+}
+
+// readManifestFile reads each of the documents of a workload manifest file.
+func readManifestFile(filename string) ([]*manifestDocument, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s, %w", filename, err)
+	}
+	defer file.Close()
+
+	return readManifestDocuments(filename, file)
+}
+
+// readManifestDocuments splits a stream of YAML documents, which are separated by ---, into its
+// documents.
+func readManifestDocuments(source string, reader io.Reader) ([]*manifestDocument, error) {
+	var documents []*manifestDocument
+
+	yamlReader := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+
+	for index := 1; ; index++ {
+		content, err := yamlReader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s, %w", source, err)
+		}
+
+		if isEmptyDocument(content) {
+			continue
+		}
+
+		// the separator which precedes the document is not part of its content
+		if lines := bytes.SplitN(content, []byte("\n"), 2); len(lines) == 2 && bytes.Equal(bytes.TrimSpace(lines[0]), []byte("---")) {
+			content = lines[1]
+		}
+
+		documents = append(documents, &manifestDocument{source: source, index: index, content: content})
+	}
+}
+
+// isEmptyDocument returns whether a YAML document contains only whitespace and comments.
+func isEmptyDocument(content []byte) bool {
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+
+		if len(line) > 0 && line[0] != '#' && !bytes.Equal(line, []byte("---")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decodeWorkload decodes a document into a v1alpha2 workload.  Documents of the v1alpha1 version of
+// the workload are converted to v1alpha2, in which case the returned version is v1alpha1.  The
+// group, version and kind of the document are validated with validateWorkload.  Errors do not
+// identify the document, which is left to the caller.
+func decodeWorkload(document *manifestDocument) (workload *tenancyv1alpha2.TanzuNamespace, version string, err error) {
+	deprecated, err := isDeprecatedWorkload(document)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workload = &tenancyv1alpha2.TanzuNamespace{}
+
+	if deprecated {
+		var deprecatedWorkload tenancyv1alpha1.TanzuNamespace
+		if err := yaml.Unmarshal(document.content, &deprecatedWorkload); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+		}
+
+		if err := deprecatedWorkload.ConvertTo(workload); err != nil {
+			return nil, "", fmt.Errorf("failed to convert workload to %s, %w", tenancyv1alpha2.GroupVersion, err)
+		}
+
+		workload.SetGroupVersionKind(workload.GetComponentGVK())
+
+		return workload, tenancyv1alpha1.GroupVersion.Version, nil
+	}
+
+	if err := yaml.Unmarshal(document.content, workload); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	if err := validateWorkload(workload); err != nil {
+		return nil, "", fmt.Errorf("error validating yaml, %w", err)
+	}
+
+	return workload, tenancyv1alpha2.GroupVersion.Version, nil
+}
+
+// isDeprecatedWorkload returns whether a document is of the deprecated v1alpha1 version of the
+// workload.
+func isDeprecatedWorkload(document *manifestDocument) (bool, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(document.content, &typeMeta); err != nil {
+		return false, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	return typeMeta.GroupVersionKind() == tenancyv1alpha1.GroupVersion.WithKind("TanzuNamespace"), nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// documentSummary is the source, index and content of a manifest document.
+type documentSummary struct {
+	source  string
+	index   int
+	content string
+}
+
+// summarizeDocuments returns the summaries of a list of manifest documents.
+func summarizeDocuments(documents []*manifestDocument) []documentSummary {
+	var summaries []documentSummary
+
+	for _, document := range documents {
+		summaries = append(summaries, documentSummary{
+			source:  document.source,
+			index:   document.index,
+			content: string(document.content),
+		})
+	}
+
+	return summaries
+}
+
+// writeManifests writes a set of manifest files, keyed by their path relative to a temporary
+// directory, and returns the directory.
+func writeManifests(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestReadManifestDocuments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []documentSummary
+	}{
+		{
+			name:    "single document",
+			content: "kind: TanzuNamespace\n",
+			want: []documentSummary{
+				{source: "test", index: 1, content: "kind: TanzuNamespace\n"},
+			},
+		},
+		{
+			name:    "leading separator",
+			content: "---\nkind: TanzuNamespace\n",
+			want: []documentSummary{
+				{source: "test", index: 1, content: "kind: TanzuNamespace\n"},
+			},
+		},
+		{
+			name:    "multiple documents",
+			content: "kind: TanzuNamespace\nmetadata:\n  name: first\n---\nkind: TanzuNamespace\nmetadata:\n  name: second\n",
+			want: []documentSummary{
+				{source: "test", index: 1, content: "kind: TanzuNamespace\nmetadata:\n  name: first\n"},
+				{source: "test", index: 2, content: "kind: TanzuNamespace\nmetadata:\n  name: second\n"},
+			},
+		},
+		{
+			name:    "empty and comment documents are skipped",
+			content: "---\n# first\n---\n\n---\nkind: TanzuNamespace\n---\n",
+			want: []documentSummary{
+				{source: "test", index: 3, content: "kind: TanzuNamespace\n"},
+			},
+		},
+		{
+			name:    "comments within a document are kept",
+			content: "# tenant\nkind: TanzuNamespace\n",
+			want: []documentSummary{
+				{source: "test", index: 1, content: "# tenant\nkind: TanzuNamespace\n"},
+			},
+		},
+		{
+			name:    "empty stream",
+			content: "",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documents, err := readManifestDocuments("test", strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("readManifestDocuments() error = %v", err)
+			}
+
+			if got := summarizeDocuments(documents); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readManifestDocuments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadManifests(t *testing.T) {
+	t.Parallel()
+
+	dir := writeManifests(t, map[string]string{
+		"first.yaml":         "kind: First\n",
+		"nested/second.yml":  "kind: Second\n---\nkind: Third\n",
+		"nested/third.JSON":  `{"kind": "Fourth"}`,
+		"nested/README.md":   "# tenants\n",
+		"nested/ignored.txt": "kind: Ignored\n",
+	})
+
+	tests := []struct {
+		name    string
+		paths   []string
+		stdin   string
+		want    []documentSummary
+		wantErr bool
+	}{
+		{
+			name:  "file",
+			paths: []string{filepath.Join(dir, "first.yaml")},
+			want: []documentSummary{
+				{source: filepath.Join(dir, "first.yaml"), index: 1, content: "kind: First\n"},
+			},
+		},
+		{
+			name:  "directory",
+			paths: []string{dir},
+			want: []documentSummary{
+				{source: filepath.Join(dir, "first.yaml"), index: 1, content: "kind: First\n"},
+				{source: filepath.Join(dir, "nested", "second.yml"), index: 1, content: "kind: Second\n"},
+				{source: filepath.Join(dir, "nested", "second.yml"), index: 2, content: "kind: Third\n"},
+				{source: filepath.Join(dir, "nested", "third.JSON"), index: 1, content: "{\"kind\": \"Fourth\"}\n"},
+			},
+		},
+		{
+			name:  "standard input and files in order",
+			paths: []string{filepath.Join(dir, "nested", "second.yml"), stdinManifest, filepath.Join(dir, "first.yaml")},
+			stdin: "kind: Stdin\n",
+			want: []documentSummary{
+				{source: filepath.Join(dir, "nested", "second.yml"), index: 1, content: "kind: Second\n"},
+				{source: filepath.Join(dir, "nested", "second.yml"), index: 2, content: "kind: Third\n"},
+				{source: "<stdin>", index: 1, content: "kind: Stdin\n"},
+				{source: filepath.Join(dir, "first.yaml"), index: 1, content: "kind: First\n"},
+			},
+		},
+		{
+			name:  "file without a manifest extension",
+			paths: []string{filepath.Join(dir, "nested", "ignored.txt")},
+			want: []documentSummary{
+				{source: filepath.Join(dir, "nested", "ignored.txt"), index: 1, content: "kind: Ignored\n"},
+			},
+		},
+		{
+			name:    "missing file",
+			paths:   []string{filepath.Join(dir, "missing.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documents, err := readManifests(tt.paths, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readManifests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := summarizeDocuments(documents); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readManifests() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,8 @@ func (c *TanzuNsCtlCommand) Run() {
 func (c *TanzuNsCtlCommand) addSubCommands() {
 	c.newInitCommand()
 	c.newGenerateCommand()
+	c.newValidateCommand()
+	c.newConvertCommand()
 	c.newVersionCommand()
 	//+kubebuilder:scaffold:operator-builder:subcommands
 }
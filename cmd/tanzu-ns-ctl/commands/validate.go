@@ -0,0 +1,140 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	tenancyv1alpha1 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha1"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+type validateCommand struct {
+	*cobra.Command
+	workloadManifests []string
+}
+
+// newValidateCommand creates a new instance of the validate subcommand.
+func (c *TanzuNsCtlCommand) newValidateCommand() {
+	v := &validateCommand{}
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a workload's custom resource manifests",
+		Long: "Validate a workload's custom resource manifests with the checks which are performed by the " +
+			"operator, without connecting to a cluster.  Exits with a non-zero status if any manifest is invalid.",
+		RunE: v.validate,
+	}
+
+	validateCmd.Flags().StringArrayVarP(
+		&v.workloadManifests,
+		"workload-manifest",
+		"w",
+		nil,
+		"Filepath to a workload manifest, a directory of workload manifests or - for standard input.  "+
+			"May be specified multiple times.",
+	)
+	validateCmd.MarkFlagRequired("workload-manifest")
+
+	c.AddCommand(validateCmd)
+}
+
+// validate validates each of the documents of the workload manifests, reporting every error rather
+// than stopping at the first invalid document.
+func (v *validateCommand) validate(cmd *cobra.Command, args []string) error {
+	documents, err := readManifests(v.workloadManifests, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	// the errors are reported below, so the usage is not useful when validation fails and the
+	// returned error is only reported once by the root command
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	// namespaces which are claimed by the workloads, which must be unique as they would otherwise
+	// be rejected by the operator
+	claimedNamespaces := map[string]*manifestDocument{}
+
+	invalid := 0
+
+	for _, document := range documents {
+		errs := validateDocument(document, claimedNamespaces)
+		if len(errs) == 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: valid\n", document)
+
+			continue
+		}
+
+		invalid++
+
+		for _, err := range errs {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", document, err)
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d documents are invalid", invalid, len(documents))
+	}
+
+	return nil
+}
+
+// validateDocument validates a document of a workload manifest, returning each of its errors.  The
+// workload is validated with the checks of the validating webhook and its child resources are
+// constructed with the checks of the generate command.
+func validateDocument(document *manifestDocument, claimedNamespaces map[string]*manifestDocument) []error {
+	workload, version, err := decodeWorkload(document)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+
+	// fields which are not part of the schema would be pruned by the cluster, which usually
+	// indicates a typo in the manifest
+	var strictErr error
+	if version == tenancyv1alpha1.GroupVersion.Version {
+		strictErr = yaml.UnmarshalStrict(document.content, &tenancyv1alpha1.TanzuNamespace{})
+	} else {
+		strictErr = yaml.UnmarshalStrict(document.content, &tenancyv1alpha2.TanzuNamespace{})
+	}
+
+	if strictErr != nil {
+		errs = append(errs, fmt.Errorf("unknown or invalid fields, %w", strictErr))
+	}
+
+	for _, fieldErr := range workload.Validate() {
+		if version != tenancyv1alpha2.GroupVersion.Version {
+			fieldErr.Detail = fmt.Sprintf("%s (field of the converted %s resource)", fieldErr.Detail, tenancyv1alpha2.GroupVersion.Version)
+		}
+
+		errs = append(errs, fieldErr)
+	}
+
+	// the child resources are constructed, as they would be by the operator and the generate
+	// command, so that errors which are only detected while constructing them are reported; the
+	// construction is skipped for an invalid spec as it would only repeat the errors above
+	if len(errs) == 0 {
+		if _, err := constructResources(workload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate child resources, %w", err))
+		}
+	}
+
+	if namespace := workload.Spec.Namespace; namespace != "" {
+		if claimedBy, found := claimedNamespaces[namespace]; found {
+			errs = append(errs, field.Duplicate(
+				field.NewPath("spec", "namespace"),
+				fmt.Sprintf("%s (already claimed by %s)", namespace, claimedBy),
+			))
+		} else {
+			claimedNamespaces[namespace] = document
+		}
+	}
+
+	return errs
+}
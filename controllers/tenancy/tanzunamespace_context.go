@@ -0,0 +1,329 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/controllers/phases"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/controllers/utils"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/dependencies"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/mutate"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/wait"
+)
+
+// TanzuNamespaceReconcileContext stores the state of a single reconcile request for a
+// TanzuNamespace object.  A new context is created for each request so that the component and its
+// resources are never shared between concurrent requests.
+type TanzuNamespaceReconcileContext struct {
+	client.Client
+	Reconciler *TanzuNamespaceReconciler
+	Context    context.Context
+	Log        logr.Logger
+	Resources  []common.ComponentResource
+	Component  *tenancyv1alpha2.TanzuNamespace
+}
+
+// Construct resources runs the methods to properly construct the resources.
+func (rc *TanzuNamespaceReconcileContext) ConstructResources() ([]metav1.Object, error) {
+
+	resourceObjects := make([]metav1.Object, len(tanzunamespace.CreateFuncs))
+
+	// create resources in memory
+	for i, f := range tanzunamespace.CreateFuncs {
+		resource, err := f(rc.Component)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects[i] = resource
+	}
+
+	// create resources in memory which may vary in number
+	for _, f := range tanzunamespace.CreateArrayFuncs {
+		resourceArray, err := f(rc.Component)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects = append(resourceObjects, resourceArray...)
+	}
+
+	tanzunamespace.SetNamespaceMetadata(rc.Component, resourceObjects)
+
+	return resourceObjects, nil
+}
+
+// GetResources will return the resources associated with the reconcile request.
+func (rc *TanzuNamespaceReconcileContext) GetResources() []common.ComponentResource {
+	return rc.Resources
+}
+
+// checkNamespaceClaim returns a terminal error if the namespace of the component has already been
+// claimed by another TanzuNamespace.  The earliest claim, by creation timestamp and then by name,
+// owns the namespace, so that a second claim which was admitted concurrently with the first never
+// modifies the namespace.  The API server is queried directly so that a claim which has not yet
+// been observed by the cache is not missed.
+func (rc *TanzuNamespaceReconcileContext) checkNamespaceClaim() error {
+	components := &tenancyv1alpha2.TanzuNamespaceList{}
+	if err := rc.GetAPIReader().List(rc.Context, components); err != nil {
+		return fmt.Errorf("unable to list existing TanzuNamespaces; %w", err)
+	}
+
+	for i := range components.Items {
+		existing := &components.Items[i]
+
+		if existing.UID == rc.Component.UID || existing.Spec.Namespace != rc.Component.Spec.Namespace {
+			continue
+		}
+
+		if claimedBefore(existing, rc.Component) {
+			return phases.NewTerminalError(fmt.Errorf("namespace %s is already claimed by TanzuNamespace %s",
+				rc.Component.Spec.Namespace, existing.Name))
+		}
+	}
+
+	return nil
+}
+
+// claimedBefore returns whether a TanzuNamespace claimed its namespace before another.
+func claimedBefore(first, second *tenancyv1alpha2.TanzuNamespace) bool {
+	if !first.CreationTimestamp.Equal(&second.CreationTimestamp) {
+		return first.CreationTimestamp.Before(&second.CreationTimestamp)
+	}
+
+	return first.Name < second.Name
+}
+
+// SetResources will create and return the resources in memory.
+func (rc *TanzuNamespaceReconcileContext) SetResources() error {
+	if err := rc.checkNamespaceClaim(); err != nil {
+		return err
+	}
+
+	// create resources in memory; errors which occur while constructing the resources are caused
+	// by an invalid spec and may therefore not be resolved by retrying
+	baseResources, err := rc.ConstructResources()
+	if err != nil {
+		return phases.NewTerminalError(err)
+	}
+
+	// loop through the in memory resources and store them on the reconcile context
+	for _, base := range baseResources {
+		// run through the mutation functions to mutate the resources
+		mutatedResources, skip, err := rc.Mutate(&base)
+		if err != nil {
+			return err
+		}
+		if skip {
+			continue
+		}
+
+		for _, mutated := range mutatedResources {
+			resourceObject := resources.NewResourceFromClient(mutated.(client.Object))
+			resourceObject.Reconciler = rc
+			resourceObject.Shared = tanzunamespace.IsSharedResource(mutated)
+
+			rc.SetResource(resourceObject)
+		}
+	}
+
+	return nil
+}
+
+// SetResource will set a resource on the objects if the relevant object does not already exist.
+func (rc *TanzuNamespaceReconcileContext) SetResource(new common.ComponentResource) {
+
+	// set and return immediately if nothing exists
+	if len(rc.Resources) == 0 {
+		rc.Resources = append(rc.Resources, new)
+
+		return
+	}
+
+	// loop through the resources and set or update when found
+	for i, existing := range rc.Resources {
+		if new.EqualGVK(existing) && new.EqualNamespaceName(existing) {
+			rc.Resources[i] = new
+
+			return
+		}
+	}
+
+	// if we haven't returned yet, we have not found the resource and must add it
+	rc.Resources = append(rc.Resources, new)
+}
+
+// CreateOrUpdate creates a resource if it does not already exist or updates a resource
+// if it does already exist.  Shared resources, which exist independently of the component, are
+// neither owned nor labelled by the component and are patched rather than updated.
+func (rc *TanzuNamespaceReconcileContext) CreateOrUpdate(
+	resource metav1.Object,
+) error {
+	shared := tanzunamespace.IsSharedResource(resource)
+
+	if !shared {
+		// set ownership on the underlying resource being created or updated
+		if err := ctrl.SetControllerReference(rc.Component, resource, rc.GetScheme()); err != nil {
+			rc.GetLogger().V(0).Info("unable to set owner reference on resource")
+
+			return err
+		}
+
+		// label the underlying resource so that it may be identified as belonging to the component
+		labels := resource.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		labels[resources.TanzuNamespaceLabel] = rc.Component.Name
+		resource.SetLabels(labels)
+	}
+
+	// create a stub object to store the current resource in the cluster so that we do not affect
+	// the desired state of the resource object in memory
+	newResource := resources.NewResourceFromClient(resource.(client.Object), rc)
+	resourceStub := &unstructured.Unstructured{}
+	resourceStub.SetGroupVersionKind(newResource.Object.GetObjectKind().GroupVersionKind())
+	oldResource := resources.NewResourceFromClient(resourceStub, rc)
+
+	if err := rc.Get(
+		rc.Context,
+		client.ObjectKeyFromObject(newResource.Object),
+		oldResource.Object,
+	); err != nil {
+		// create the resource if we cannot find one
+		if errors.IsNotFound(err) {
+			if err := newResource.Create(); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+	} else if shared {
+		if err := newResource.PatchShared(oldResource); err != nil {
+			return err
+		}
+	} else {
+		// preserve the owner references of the existing resource which do not control it, so that
+		// adopting a resource does not remove its other owners; server-side apply merges the owner
+		// references of each field manager so they are only preserved for a merge patch
+		if !resources.ServerSideApply {
+			ownerReferences := resource.GetOwnerReferences()
+
+			for _, ownerReference := range oldResource.Object.GetOwnerReferences() {
+				if ownerReference.UID == rc.Component.UID ||
+					(ownerReference.Controller != nil && *ownerReference.Controller) {
+					continue
+				}
+
+				ownerReferences = append(ownerReferences, ownerReference)
+			}
+
+			resource.SetOwnerReferences(ownerReferences)
+		}
+
+		// update the resource
+		if err := newResource.Update(oldResource); err != nil {
+			return err
+		}
+	}
+
+	return utils.Watch(rc, newResource.Object)
+}
+
+// GetLogger returns the logger for the reconcile request.
+func (rc *TanzuNamespaceReconcileContext) GetLogger() logr.Logger {
+	return rc.Log
+}
+
+// GetClient returns the client from the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetClient() client.Client {
+	return rc.Client
+}
+
+// GetAPIReader returns the reader from the reconciler, which reads directly from the API server
+// rather than from the cache.
+func (rc *TanzuNamespaceReconcileContext) GetAPIReader() client.Reader {
+	return rc.Reconciler.APIReader
+}
+
+// GetScheme returns the scheme from the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetScheme() *runtime.Scheme {
+	return rc.Reconciler.Scheme
+}
+
+// GetContext returns the context for the reconcile request.
+func (rc *TanzuNamespaceReconcileContext) GetContext() context.Context {
+	return rc.Context
+}
+
+// GetName returns the name of the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetName() string {
+	return rc.Reconciler.Name
+}
+
+// GetComponent returns the component the reconcile request is operating against.
+func (rc *TanzuNamespaceReconcileContext) GetComponent() common.Component {
+	return rc.Component
+}
+
+// GetController returns the controller object associated with the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetController() controller.Controller {
+	return rc.Reconciler.Controller
+}
+
+// GetEventRecorder returns the event recorder from the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetEventRecorder() record.EventRecorder {
+	return rc.Reconciler.recorder
+}
+
+// GetWatches returns the objects which are current being watched by the reconciler.
+func (rc *TanzuNamespaceReconcileContext) GetWatches() []client.Object {
+	return rc.Reconciler.getWatches()
+}
+
+// SetWatch appends a watch to the list of currently watched objects.
+func (rc *TanzuNamespaceReconcileContext) SetWatch(watch client.Object) {
+	rc.Reconciler.setWatch(watch)
+}
+
+// UpdateStatus updates the status for a component.
+func (rc *TanzuNamespaceReconcileContext) UpdateStatus() error {
+	return rc.Status().Update(rc.Context, rc.Component)
+}
+
+// CheckReady will return whether a component is ready.
+func (rc *TanzuNamespaceReconcileContext) CheckReady() (bool, error) {
+	return dependencies.TanzuNamespaceCheckReady(rc)
+}
+
+// Mutate will run the mutate phase of a resource.
+func (rc *TanzuNamespaceReconcileContext) Mutate(
+	object *metav1.Object,
+) ([]metav1.Object, bool, error) {
+	return mutate.TanzuNamespaceMutate(rc, object)
+}
+
+// Wait will run the wait phase of a resource.
+func (rc *TanzuNamespaceReconcileContext) Wait(
+	object *metav1.Object,
+) (bool, error) {
+	return wait.TanzuNamespaceWait(rc, object)
+}
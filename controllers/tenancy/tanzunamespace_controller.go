@@ -6,47 +6,81 @@ package tenancy
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
-	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
 	"github.com/vmware-tanzu-labs/namespace-operator/internal/controllers/phases"
 	"github.com/vmware-tanzu-labs/namespace-operator/internal/controllers/utils"
-	"github.com/vmware-tanzu-labs/namespace-operator/internal/dependencies"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
 	"github.com/vmware-tanzu-labs/namespace-operator/internal/mutate"
-	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
-	"github.com/vmware-tanzu-labs/namespace-operator/internal/wait"
 )
 
-// TanzuNamespaceReconciler reconciles a TanzuNamespace object.
+// eventDeduplicationWindow is the window in which identical events for a TanzuNamespace are only
+// recorded once.
+const eventDeduplicationWindow = 10 * time.Minute
+
+// sourceSecretIndexKey is the field index of the source secrets which the image pull secrets of a
+// TanzuNamespace are copied from.
+const sourceSecretIndexKey = ".spec.imagePullSecrets.sourceSecret"
+
+// sourceSecretRequestsTimeout is the maximum duration of listing the TanzuNamespaces which copy an
+// image pull secret from a source secret.
+const sourceSecretRequestsTimeout = 10 * time.Second
+
+// TanzuNamespaceReconciler reconciles a TanzuNamespace object.  The reconciler is long-lived and
+// shared between all reconcile requests; any state which belongs to an individual request is stored
+// on a TanzuNamespaceReconcileContext instead so that requests may be processed concurrently.
 type TanzuNamespaceReconciler struct {
 	client.Client
-	Name       string
-	Log        logr.Logger
-	Scheme     *runtime.Scheme
-	Context    context.Context
-	Controller controller.Controller
-	Watches    []client.Object
-	Resources  []common.ComponentResource
-	Component  *tenancyv1alpha2.TanzuNamespace
+	APIReader               client.Reader
+	Name                    string
+	Log                     logr.Logger
+	Scheme                  *runtime.Scheme
+	Controller              controller.Controller
+	Recorder                record.EventRecorder
+	MaxConcurrentReconciles int
+
+	watchesLock sync.RWMutex
+	watches     []client.Object
+
+	// recorder wraps the Recorder so that repeated events are deduplicated
+	recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=tenancy.platform.cnr.vmware.com,resources=tanzunamespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=tenancy.platform.cnr.vmware.com,resources=tanzunamespaces/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tenancy.platform.cnr.vmware.com,resources=tanzunamespaces/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=limitranges,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// the operator may not escalate, so it must hold each permission of the built-in developer Role
+// +kubebuilder:rbac:groups=core,resources=configmaps;services;pods;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=admin;edit;view
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -58,27 +92,53 @@ type TanzuNamespaceReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.2/pkg/reconcile
 func (r *TanzuNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.Context = ctx
 	log := r.Log.WithValues("tanzunamespace", req.NamespacedName)
 
-	// get and store the component
-	r.Component = &tenancyv1alpha2.TanzuNamespace{}
-	if err := r.Get(r.Context, req.NamespacedName, r.Component); err != nil {
+	// get and store the component on a context which is specific to this request
+	rc := &TanzuNamespaceReconcileContext{
+		Client:     r.Client,
+		Reconciler: r,
+		Context:    ctx,
+		Log:        log,
+		Component:  &tenancyv1alpha2.TanzuNamespace{},
+	}
+
+	if err := r.Get(ctx, req.NamespacedName, rc.Component); err != nil {
 		log.V(0).Info("unable to fetch TanzuNamespace")
 
 		return ctrl.Result{}, utils.IgnoreNotFound(err)
 	}
 
-	// get and store the resources
-	if err := r.SetResources(); err != nil {
-		return ctrl.Result{}, err
+	// skip the phases while the component is suspended, unless the component is being deleted so
+	// that the deletion policy is applied and the finalizer is removed
+	if rc.Component.GetDeletionTimestamp().IsZero() {
+		suspended, err := phases.HandleSuspension(rc)
+		if err != nil || suspended {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// get and store the resources; resources are not needed when the component is being deleted
+	// as the deletion phases operate against the resources which have previously been created.  An
+	// invalid spec is reported as a failure of the pre-flight phase, which fails fast before any
+	// resource is modified.
+	if rc.Component.GetDeletionTimestamp().IsZero() {
+		if err := rc.SetResources(); err != nil {
+			if phases.IsTerminalError(err) {
+				return phases.HandlePhaseExit(rc, &phases.PreFlightPhase{}, false, err)
+			}
+
+			return ctrl.Result{}, err
+		}
 	}
 
 	// execute the phases
-	for _, phase := range utils.Phases(r.Component) {
-		r.GetLogger().V(7).Info(fmt.Sprintf("enter phase: %T", phase))
-		proceed, err := phase.Execute(r)
-		result, err := phases.HandlePhaseExit(r, phase, proceed, err)
+	for _, phase := range utils.Phases(rc.Component) {
+		log.V(7).Info(fmt.Sprintf("enter phase: %T", phase))
+		start := time.Now()
+		proceed, err := phase.Execute(rc)
+		metrics.ObservePhase(phases.GetPhaseName(phase), start, err)
+		result, err := phases.HandlePhaseExit(rc, phase, proceed, err)
 
 		// return only if we have an error or are told not to proceed
 		if err != nil || !proceed {
@@ -87,214 +147,126 @@ func (r *TanzuNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return result, err
 		}
 
-		r.GetLogger().V(5).Info(fmt.Sprintf("completed phase: %T", phase))
+		log.V(5).Info(fmt.Sprintf("completed phase: %T", phase))
 	}
 
 	return phases.DefaultReconcileResult(), nil
 }
 
-// Construct resources runs the methods to properly construct the resources.
-func (r *TanzuNamespaceReconciler) ConstructResources() ([]metav1.Object, error) {
-
-	resourceObjects := make([]metav1.Object, len(tanzunamespace.CreateFuncs))
+// GetName returns the name of the reconciler.
+func (r *TanzuNamespaceReconciler) GetName() string {
+	return r.Name
+}
 
-	// create resources in memory
-	for i, f := range tanzunamespace.CreateFuncs {
-		resource, err := f(r.Component)
-		if err != nil {
-			return nil, err
-		}
+// getWatches returns a copy of the objects which are currently being watched by the reconciler.
+func (r *TanzuNamespaceReconciler) getWatches() []client.Object {
+	r.watchesLock.RLock()
+	defer r.watchesLock.RUnlock()
 
-		resourceObjects[i] = resource
-	}
+	watches := make([]client.Object, len(r.watches))
+	copy(watches, r.watches)
 
-	return resourceObjects, nil
+	return watches
 }
 
-// GetResources will return the resources associated with the reconciler.
-func (r *TanzuNamespaceReconciler) GetResources() []common.ComponentResource {
-	return r.Resources
+// setWatch appends a watch to the list of currently watched objects.
+func (r *TanzuNamespaceReconciler) setWatch(watch client.Object) {
+	r.watchesLock.Lock()
+	defer r.watchesLock.Unlock()
+
+	r.watches = append(r.watches, watch)
 }
 
-// SetResources will create and return the resources in memory.
-func (r *TanzuNamespaceReconciler) SetResources() error {
-	// create resources in memory
-	baseResources, err := r.ConstructResources()
-	if err != nil {
-		return err
+// sourceSecretNames returns the names of the source secrets which the image pull secrets of a
+// TanzuNamespace are copied from.  It is used to index TanzuNamespaces by their source secrets.
+func sourceSecretNames(object client.Object) []string {
+	component, ok := object.(*tenancyv1alpha2.TanzuNamespace)
+	if !ok {
+		return nil
 	}
 
-	// loop through the in memory resources and store them on the reconciler
-	for _, base := range baseResources {
-		// run through the mutation functions to mutate the resources
-		mutatedResources, skip, err := r.Mutate(&base)
-		if err != nil {
-			return err
-		}
-		if skip {
-			continue
-		}
-
-		for _, mutated := range mutatedResources {
-			resourceObject := resources.NewResourceFromClient(mutated.(client.Object))
-			resourceObject.Reconciler = r
-
-			r.SetResource(resourceObject)
-		}
+	names := make([]string, 0, len(component.Spec.ImagePullSecrets))
+	for _, imagePullSecret := range component.Spec.ImagePullSecrets {
+		names = append(names, imagePullSecret.GetSourceSecret())
 	}
 
-	return nil
+	return names
 }
 
-// SetResource will set a resource on the objects if the relevant object does not already exist.
-func (r *TanzuNamespaceReconciler) SetResource(new common.ComponentResource) {
+// imagePullSecretRequests returns a reconcile request for each TanzuNamespace which copies an image
+// pull secret from the source secret so that image pull secrets are kept in sync with their source.
+func (r *TanzuNamespaceReconciler) imagePullSecretRequests(sourceSecret client.Object) []reconcile.Request {
+	ctx, cancel := context.WithTimeout(context.Background(), sourceSecretRequestsTimeout)
+	defer cancel()
 
-	// set and return immediately if nothing exists
-	if len(r.Resources) == 0 {
-		r.Resources = append(r.Resources, new)
+	components := &tenancyv1alpha2.TanzuNamespaceList{}
+	if err := r.List(ctx, components, client.MatchingFields{sourceSecretIndexKey: sourceSecret.GetName()}); err != nil {
+		r.Log.Error(err, "unable to list TanzuNamespaces for source secret", "secret", sourceSecret.GetName())
 
-		return
+		return nil
 	}
 
-	// loop through the resources and set or update when found
-	for i, existing := range r.Resources {
-		if new.EqualGVK(existing) && new.EqualNamespaceName(existing) {
-			r.Resources[i] = new
-
-			return
-		}
+	requests := make([]reconcile.Request, 0, len(components.Items))
+	for i := range components.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&components.Items[i]),
+		})
 	}
 
-	// if we haven't returned yet, we have not found the resource and must add it
-	r.Resources = append(r.Resources, new)
+	return requests
 }
 
-// CreateOrUpdate creates a resource if it does not already exist or updates a resource
-// if it does already exist.
-func (r *TanzuNamespaceReconciler) CreateOrUpdate(
-	resource metav1.Object,
-) error {
-	// set ownership on the underlying resource being created or updated
-	if err := ctrl.SetControllerReference(r.Component, resource, r.Scheme); err != nil {
-		r.GetLogger().V(0).Info("unable to set owner reference on resource")
-
-		return err
+func (r *TanzuNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	options := controller.Options{
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		RateLimiter:             utils.NewDefaultRateLimiter(5*time.Microsecond, 5*time.Minute),
 	}
 
-	// create a stub object to store the current resource in the cluster so that we do not affect
-	// the desired state of the resource object in memory
-	newResource := resources.NewResourceFromClient(resource.(client.Object), r)
-	resourceStub := &unstructured.Unstructured{}
-	resourceStub.SetGroupVersionKind(newResource.Object.GetObjectKind().GroupVersionKind())
-	oldResource := resources.NewResourceFromClient(resourceStub, r)
-
-	if err := r.Get(
-		r.Context,
-		client.ObjectKeyFromObject(newResource.Object),
-		oldResource.Object,
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&tenancyv1alpha2.TanzuNamespace{},
+		sourceSecretIndexKey,
+		sourceSecretNames,
 	); err != nil {
-		// create the resource if we cannot find one
-		if errors.IsNotFound(err) {
-			if err := newResource.Create(); err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
-	} else {
-		// update the resource
-		if err := newResource.Update(oldResource); err != nil {
-			return err
-		}
+		return err
 	}
 
-	return utils.Watch(r, newResource.Object)
-}
-
-// GetLogger returns the logger from the reconciler.
-func (r *TanzuNamespaceReconciler) GetLogger() logr.Logger {
-	return r.Log
-}
-
-// GetClient returns the client from the reconciler.
-func (r *TanzuNamespaceReconciler) GetClient() client.Client {
-	return r.Client
-}
-
-// GetScheme returns the scheme from the reconciler.
-func (r *TanzuNamespaceReconciler) GetScheme() *runtime.Scheme {
-	return r.Scheme
-}
-
-// GetContext returns the context from the reconciler.
-func (r *TanzuNamespaceReconciler) GetContext() context.Context {
-	return r.Context
-}
-
-// GetName returns the name of the reconciler.
-func (r *TanzuNamespaceReconciler) GetName() string {
-	return r.Name
-}
-
-// GetComponent returns the component the reconciler is operating against.
-func (r *TanzuNamespaceReconciler) GetComponent() common.Component {
-	return r.Component
-}
-
-// GetController returns the controller object associated with the reconciler.
-func (r *TanzuNamespaceReconciler) GetController() controller.Controller {
-	return r.Controller
-}
-
-// GetWatches returns the objects which are current being watched by the reconciler.
-func (r *TanzuNamespaceReconciler) GetWatches() []client.Object {
-	return r.Watches
-}
-
-// SetWatch appends a watch to the list of currently watched objects.
-func (r *TanzuNamespaceReconciler) SetWatch(watch client.Object) {
-	r.Watches = append(r.Watches, watch)
-}
-
-// UpdateStatus updates the status for a component.
-func (r *TanzuNamespaceReconciler) UpdateStatus() error {
-	return r.Status().Update(r.Context, r.Component)
-}
-
-// CheckReady will return whether a component is ready.
-func (r *TanzuNamespaceReconciler) CheckReady() (bool, error) {
-	return dependencies.TanzuNamespaceCheckReady(r)
-}
-
-// Mutate will run the mutate phase of a resource.
-func (r *TanzuNamespaceReconciler) Mutate(
-	object *metav1.Object,
-) ([]metav1.Object, bool, error) {
-	return mutate.TanzuNamespaceMutate(r, object)
-}
-
-// Wait will run the wait phase of a resource.
-func (r *TanzuNamespaceReconciler) Wait(
-	object *metav1.Object,
-) (bool, error) {
-	return wait.TanzuNamespaceWait(r, object)
-}
+	// source secrets are watched through a cache which is restricted to the source namespace so
+	// that the secrets of every other namespace are not cached by the operator
+	sourceSecretCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: mutate.ImagePullSecretSourceNamespace,
+	})
+	if err != nil {
+		return err
+	}
 
-func (r *TanzuNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	options := controller.Options{
-		RateLimiter: utils.NewDefaultRateLimiter(5*time.Microsecond, 5*time.Minute),
+	if err := mgr.Add(sourceSecretCache); err != nil {
+		return err
 	}
 
 	baseController, err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
-		WithEventFilter(utils.ComponentPredicates()).
-		For(&tenancyv1alpha2.TanzuNamespace{}).
+		For(&tenancyv1alpha2.TanzuNamespace{}, builder.WithPredicates(utils.ComponentPredicates())).
+		Watches(
+			source.NewKindWithCache(&corev1.Secret{}, sourceSecretCache),
+			handler.EnqueueRequestsFromMapFunc(r.imagePullSecretRequests),
+		).
 		Build(r)
 	if err != nil {
 		return err
 	}
 
 	r.Controller = baseController
+	r.recorder = utils.NewDedupingRecorder(r.Recorder, eventDeduplicationWindow)
+
+	if err := ctrlmetrics.Registry.Register(&tanzuNamespaceCollector{
+		reader: mgr.GetClient(),
+		log:    r.Log.WithName("metrics"),
+	}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,111 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
+)
+
+// metricsCollectTimeout is the maximum amount of time in which the objects which are used to
+// collect the metrics of a scrape are listed.
+const metricsCollectTimeout = 10 * time.Second
+
+var (
+	tanzuNamespacesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "", "tanzunamespaces"),
+		"Number of TanzuNamespaces by ready state.",
+		[]string{"ready"},
+		nil,
+	)
+
+	resourceQuotaUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "resource_quota", "used"),
+		"Current usage of a resource in a ResourceQuota managed by a TanzuNamespace.",
+		[]string{"tanzunamespace", "namespace", "resourcequota", "resource"},
+		nil,
+	)
+
+	resourceQuotaHardDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "resource_quota", "hard"),
+		"Hard limit of a resource in a ResourceQuota managed by a TanzuNamespace.",
+		[]string{"tanzunamespace", "namespace", "resourcequota", "resource"},
+		nil,
+	)
+)
+
+// tanzuNamespaceCollector collects the metrics which describe the current state of the
+// TanzuNamespaces and their resource quotas from the cache at the time of a scrape.
+type tanzuNamespaceCollector struct {
+	reader client.Reader
+	log    logr.Logger
+}
+
+// Describe implements prometheus.Collector.
+func (collector *tanzuNamespaceCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- tanzuNamespacesDesc
+	descs <- resourceQuotaUsedDesc
+	descs <- resourceQuotaHardDesc
+}
+
+// Collect implements prometheus.Collector.
+func (collector *tanzuNamespaceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsCollectTimeout)
+	defer cancel()
+
+	components := &tenancyv1alpha2.TanzuNamespaceList{}
+	if err := collector.reader.List(ctx, components); err != nil {
+		collector.log.Error(err, "unable to list TanzuNamespaces for metrics")
+	} else {
+		// the Ready condition reflects the most recent reconciliation, whereas the created status
+		// remains set once the TanzuNamespace has first become ready
+		counts := map[bool]int{true: 0, false: 0}
+		for i := range components.Items {
+			counts[meta.IsStatusConditionTrue(components.Items[i].Status.StatusConditions, common.ConditionTypeReady)]++
+		}
+
+		for ready, count := range counts {
+			ch <- prometheus.MustNewConstMetric(
+				tanzuNamespacesDesc, prometheus.GaugeValue, float64(count), strconv.FormatBool(ready),
+			)
+		}
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := collector.reader.List(ctx, quotas, client.HasLabels{resources.TanzuNamespaceLabel}); err != nil {
+		collector.log.Error(err, "unable to list resource quotas for metrics")
+
+		return
+	}
+
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		tenant := quota.Labels[resources.TanzuNamespaceLabel]
+
+		for resource, hard := range quota.Status.Hard {
+			ch <- prometheus.MustNewConstMetric(
+				resourceQuotaHardDesc, prometheus.GaugeValue, hard.AsApproximateFloat64(),
+				tenant, quota.Namespace, quota.Name, string(resource),
+			)
+
+			used := quota.Status.Used[resource]
+			ch <- prometheus.MustNewConstMetric(
+				resourceQuotaUsedDesc, prometheus.GaugeValue, used.AsApproximateFloat64(),
+				tenant, quota.Namespace, quota.Name, string(resource),
+			)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tenancy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/controllers/utils"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/dependencies"
+)
+
+// quotaStatusRefreshInterval is the interval in which the changes to the status of the quotas of a
+// TanzuNamespace are coalesced into a single refresh of its status.
+const quotaStatusRefreshInterval = 10 * time.Second
+
+// TanzuNamespaceQuotaStatusReconciler refreshes the quota usage which is reported in the status of
+// a TanzuNamespace when the status of one of its ResourceQuotas changes.  The usage of a quota
+// changes whenever pods are created or deleted within the namespace, so these changes are handled
+// separately from the TanzuNamespaceReconciler, which would otherwise run each of its phases
+// against each of the child resources of the TanzuNamespace.
+type TanzuNamespaceQuotaStatusReconciler struct {
+	client.Client
+	Name string
+	Log  logr.Logger
+}
+
+// Reconcile refreshes the quota status of a TanzuNamespace.  Only the quota fields of the status
+// are patched, so that the status which is owned by the TanzuNamespaceReconciler is not modified.
+func (r *TanzuNamespaceQuotaStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("tanzunamespace", req.NamespacedName)
+
+	component := &tenancyv1alpha2.TanzuNamespace{}
+	if err := r.Get(ctx, req.NamespacedName, component); err != nil {
+		return ctrl.Result{}, utils.IgnoreNotFound(err)
+	}
+
+	if !component.GetDeletionTimestamp().IsZero() || component.IsSuspended() {
+		return ctrl.Result{}, nil
+	}
+
+	original := component.DeepCopy()
+
+	if err := dependencies.SetResourceQuotaStatus(ctx, r.Client, component); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(original.Status, component.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	log.V(5).Info("refreshing quota status")
+
+	if err := r.Status().Patch(ctx, component, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, utils.IgnoreNotFound(err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// GetName returns the name of the reconciler.
+func (r *TanzuNamespaceQuotaStatusReconciler) GetName() string {
+	return r.Name
+}
+
+// SetupWithManager registers the reconciler with the manager.  Only changes to the status of the
+// ResourceQuotas which are controlled by a TanzuNamespace are reconciled, and the changes to the
+// quotas of a TanzuNamespace within the refresh interval are coalesced into a single refresh.
+func (r *TanzuNamespaceQuotaStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	quotaStatusController, err := controller.New("tanzunamespace-quota-status", mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return err
+	}
+
+	return quotaStatusController.Watch(
+		&source.Kind{Type: &corev1.ResourceQuota{}},
+		utils.EnqueueClusterControllerAfter(tenancyv1alpha2.GroupVersion.WithKind("TanzuNamespace").GroupKind(), quotaStatusRefreshInterval),
+		utils.StatusPredicates(),
+	)
+}
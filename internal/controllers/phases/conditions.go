@@ -0,0 +1,105 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+)
+
+// Below are the reasons of the standard conditions which summarize the reconciliation of a
+// component.
+const (
+	reasonSucceeded   = "Succeeded"
+	reasonProgressing = "Progressing"
+	reasonPhaseFailed = "PhaseFailed"
+	reasonDeleting    = "Deleting"
+)
+
+// setStatusConditions sets the standard conditions and the observed generation of a component
+// given the condition of the phase which is exiting.  The condition of the phase is recorded as a
+// condition whose type is the name of the phase, while the Ready, Reconciling and Stalled
+// conditions summarize the reconciliation as a whole.  A failed phase only stalls the
+// reconciliation when its error is terminal; other errors are retried, so the component remains
+// reconciling.
+func setStatusConditions(r common.ComponentReconciler, phase Phase, condition common.PhaseCondition, terminal bool) {
+	component := r.GetComponent()
+
+	var generation int64
+	if object, ok := component.(client.Object); ok {
+		generation = object.GetGeneration()
+	}
+
+	setCondition := func(conditionType string, status metav1.ConditionStatus, reason, message string) {
+		component.SetStatusCondition(metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	setCondition(condition.Phase, phaseConditionStatus(condition.State), string(condition.State), condition.Message)
+
+	switch {
+	case condition.State == common.PhaseStateFailed && terminal:
+		message := fmt.Sprintf("phase %s failed; %s", condition.Phase, condition.Message)
+		setCondition(common.ConditionTypeReady, metav1.ConditionFalse, reasonPhaseFailed, message)
+		setCondition(common.ConditionTypeReconciling, metav1.ConditionFalse, reasonPhaseFailed, message)
+		setCondition(common.ConditionTypeStalled, metav1.ConditionTrue, reasonPhaseFailed, message)
+	case condition.State == common.PhaseStateFailed:
+		message := fmt.Sprintf("phase %s failed and will be retried; %s", condition.Phase, condition.Message)
+		setCondition(common.ConditionTypeReady, metav1.ConditionFalse, reasonPhaseFailed, message)
+		setCondition(common.ConditionTypeReconciling, metav1.ConditionTrue, reasonPhaseFailed, message)
+		setCondition(common.ConditionTypeStalled, metav1.ConditionFalse, reasonPhaseFailed, message)
+	case isPhase(phase, &DeletionPhase{}):
+		setCondition(common.ConditionTypeReady, metav1.ConditionFalse, reasonDeleting, "deletion policy is being applied")
+	case condition.State == common.PhaseStatePending:
+		message := fmt.Sprintf("waiting for phase %s", condition.Phase)
+		setCondition(common.ConditionTypeReady, metav1.ConditionFalse, reasonProgressing, message)
+		setCondition(common.ConditionTypeReconciling, metav1.ConditionTrue, reasonProgressing, message)
+		setCondition(common.ConditionTypeStalled, metav1.ConditionFalse, reasonProgressing, message)
+	case isPhase(phase, &CompletePhase{}):
+		message := "successfully reconciled"
+		setCondition(common.ConditionTypeReady, metav1.ConditionTrue, reasonSucceeded, message)
+		setCondition(common.ConditionTypeReconciling, metav1.ConditionFalse, reasonSucceeded, message)
+		setCondition(common.ConditionTypeStalled, metav1.ConditionFalse, reasonSucceeded, message)
+	default:
+		// the component is only reconciling if it is not ready for the current generation, so that
+		// periodic reconciliation of a ready component does not flap the conditions
+		ready := meta.FindStatusCondition(component.GetStatusConditions(), common.ConditionTypeReady)
+		if ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == generation {
+			break
+		}
+
+		message := fmt.Sprintf("completed phase %s", condition.Phase)
+		setCondition(common.ConditionTypeReconciling, metav1.ConditionTrue, reasonProgressing, message)
+		setCondition(common.ConditionTypeStalled, metav1.ConditionFalse, reasonProgressing, message)
+	}
+
+	component.SetObservedGeneration(generation)
+}
+
+// phaseConditionStatus returns the status of a standard condition given the state of a phase.
+func phaseConditionStatus(state common.PhaseState) metav1.ConditionStatus {
+	switch state {
+	case common.PhaseStateComplete:
+		return metav1.ConditionTrue
+	case common.PhaseStateFailed:
+		return metav1.ConditionFalse
+	default:
+		return metav1.ConditionUnknown
+	}
+}
+
+// isPhase returns whether a phase is of the same type as another phase.
+func isPhase(phase, compared Phase) bool {
+	return GetPhaseName(phase) == GetPhaseName(compared)
+}
@@ -52,5 +52,11 @@ func (phase *CreateResourcesPhase) Execute(
 		}
 	}
 
+	// prune the resources which are no longer desired only once all of the desired resources
+	// have been created
+	if err := pruneResources(r); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
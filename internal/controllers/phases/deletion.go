@@ -0,0 +1,189 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
+)
+
+// DeletionPhase.DefaultRequeue executes checking for a parent components readiness status.
+func (phase *DeletionPhase) DefaultRequeue() ctrl.Result {
+	return Requeue()
+}
+
+// DeletionPhase.Execute executes the deletion policy of a component against its child resources
+// and removes the finalizer from the component.  Child resources which are retained have their
+// owner references and labels removed so that they are not garbage collected along with the
+// component.  Child resources which are deleted are garbage collected once the finalizer has been
+// removed.
+func (phase *DeletionPhase) Execute(
+	r common.ComponentReconciler,
+) (proceedToNextPhase bool, err error) {
+	component, err := componentObject(r)
+	if err != nil {
+		return false, err
+	}
+
+	if !controllerutil.ContainsFinalizer(component, Finalizer) {
+		return true, nil
+	}
+
+	retain, reason, err := retainResources(r)
+	if err != nil {
+		return false, err
+	}
+
+	for _, resource := range r.GetComponent().GetResources() {
+		condition := resource.ResourceCondition
+		condition.LastModified = time.Now().UTC().String()
+
+		if retain {
+			if err := releaseResource(r, component, resource); err != nil {
+				return false, err
+			}
+
+			condition.Message = "resource retained; " + reason
+		} else {
+			condition.Message = "resource deleted; " + reason
+		}
+
+		resource.ResourceCondition = condition
+		r.GetComponent().SetResource(resource)
+	}
+
+	r.GetLogger().V(0).Info(fmt.Sprintf("applied deletion policy; retained: [%t], reason: [%s]", retain, reason))
+
+	if retain {
+		resources.RecordEvent(r, corev1.EventTypeNormal, "ResourcesRetained", "child resources retained; "+reason)
+	} else {
+		resources.RecordEvent(r, corev1.EventTypeNormal, "ResourcesDeleted", "child resources deleted; "+reason)
+	}
+
+	// record the outcome prior to removing the finalizer, after which the component may no longer
+	// exist in the cluster
+	if err := r.UpdateStatus(); err != nil {
+		return false, err
+	}
+
+	controllerutil.RemoveFinalizer(component, Finalizer)
+
+	if err := r.Update(r.GetContext(), component); err != nil {
+		return false, fmt.Errorf("unable to remove finalizer %s; %w", Finalizer, err)
+	}
+
+	return true, nil
+}
+
+// retainResources returns whether the child resources of a component should be retained, along
+// with the reason why.
+func retainResources(r common.ComponentReconciler) (bool, string, error) {
+	policy := r.GetComponent().GetDeletionPolicy()
+
+	switch policy {
+	case common.DeletionPolicyRetain:
+		return true, fmt.Sprintf("deletion policy is %s", policy), nil
+	case common.DeletionPolicyRetainIfNotEmpty:
+		for _, resource := range r.GetComponent().GetResources() {
+			if resource.Group != "" || resource.Kind != resources.NamespaceKind {
+				continue
+			}
+
+			empty, err := namespaceIsEmpty(r, resource.Name)
+			if err != nil {
+				return false, "", err
+			}
+
+			if !empty {
+				return true, fmt.Sprintf("deletion policy is %s and namespace %s is not empty", policy, resource.Name), nil
+			}
+		}
+
+		return false, fmt.Sprintf("deletion policy is %s and all namespaces are empty", policy), nil
+	default:
+		return false, fmt.Sprintf("deletion policy is %s", policy), nil
+	}
+}
+
+// namespaceIsEmpty returns whether a namespace contains no pods and no persistent volume claims.
+// The API server is queried directly so that pods and persistent volume claims are not cached.
+func namespaceIsEmpty(r common.ComponentReconciler, namespace string) (bool, error) {
+	for _, list := range []client.ObjectList{
+		&corev1.PodList{},
+		&corev1.PersistentVolumeClaimList{},
+	} {
+		if err := r.GetAPIReader().List(
+			r.GetContext(),
+			list,
+			client.InNamespace(namespace),
+			client.Limit(1),
+		); err != nil {
+			return false, fmt.Errorf("unable to determine if namespace %s is empty; %w", namespace, err)
+		}
+
+		if meta.LenList(list) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// releaseResource removes the owner reference to the component and the labels which identify the
+// component from a child resource, so that the child resource is retained when the component is
+// deleted.
+func releaseResource(r common.ComponentReconciler, component client.Object, resource common.Resource) error {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   resource.Group,
+		Version: resource.Version,
+		Kind:    resource.Kind,
+	})
+
+	if err := r.Get(
+		r.GetContext(),
+		types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace},
+		object,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to get resource %s %s; %w", resource.Kind, resource.Name, err)
+	}
+
+	ownerReferences := []metav1.OwnerReference{}
+
+	for _, ownerReference := range object.GetOwnerReferences() {
+		if ownerReference.UID != component.GetUID() {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+
+	object.SetOwnerReferences(ownerReferences)
+
+	labels := object.GetLabels()
+	delete(labels, resources.TanzuNamespaceLabel)
+	object.SetLabels(labels)
+
+	if err := r.Update(r.GetContext(), object); err != nil {
+		return fmt.Errorf("unable to release resource %s %s; %w", resource.Kind, resource.Name, err)
+	}
+
+	return nil
+}
@@ -4,15 +4,12 @@
 package phases
 
 import (
-	"fmt"
-
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
-	"github.com/vmware-tanzu-labs/namespace-operator/internal/helpers"
 )
 
 // DependencyPhase.DefaultRequeue executes checking for a parent components readiness status.
@@ -20,24 +17,21 @@ func (phase *DependencyPhase) DefaultRequeue() ctrl.Result {
 	return Requeue()
 }
 
-// DependencyPhase.Execute executes a dependency check prior to attempting to create resources.
+// DependencyPhase.Execute executes a dependency check prior to attempting to create resources.  Each
+// component is treated as an individual tenant, so only the dependencies of the component which is
+// being reconciled are considered.
 func (phase *DependencyPhase) Execute(r common.ComponentReconciler) (proceedToNextPhase bool, err error) {
 	// dependencies
 	component := r.GetComponent()
 
-	if !collectionConfigIsReady(r) {
-		return false, nil
-	}
-
-	// TODO: set DependenciesSatisfied field (see next TODO below)
 	if !component.GetDependencyStatus() {
 		satisfied, err := dependenciesSatisfied(r)
 		if err != nil || !satisfied {
 			return false, err
 		}
 
-		// dependencies satisfied; set and update status and continue
-		// TODO: needs implemented
+		// dependencies satisfied; set the status which is persisted upon exiting the phase
+		component.SetDependencyStatus(true)
 	}
 
 	return true, nil
@@ -79,25 +73,3 @@ func dependencySatisfied(r common.ComponentReconciler, dependency common.Compone
 
 	return status, nil
 }
-
-// collectionConfigIsReady determines if a component's collection is ready.
-func collectionConfigIsReady(r common.ComponentReconciler) bool {
-	// get a list of configurations from the cluster
-	collectionConfigs, err := helpers.GetCollectionConfigs(r)
-	if err != nil {
-		r.GetLogger().V(0).Info("unable to find resource of kind: [" + helpers.CollectionAPIKind + "]")
-
-		return false
-	}
-
-	// configuration is not ready if we do not have exactly one configuration
-	if len(collectionConfigs.Items) != 1 {
-		r.GetLogger().V(0).Info(
-			fmt.Sprintf("expected only 1 resource of kind: [%s]; found %v", helpers.CollectionAPIKind, len(collectionConfigs.Items)),
-		)
-
-		return false
-	}
-
-	return true
-}
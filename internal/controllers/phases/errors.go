@@ -0,0 +1,37 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	"errors"
+)
+
+// TerminalError is an error which may not be resolved by retrying a phase, such as an invalid
+// spec or the refusal to adopt an existing resource.  Terminal errors stall the reconciliation of
+// a component until it, or the conflicting resource, is changed.
+type TerminalError struct {
+	err error
+}
+
+// NewTerminalError returns a terminal error which wraps an error.
+func NewTerminalError(err error) error {
+	return &TerminalError{err: err}
+}
+
+// Error returns the message of the wrapped error.
+func (terminalError *TerminalError) Error() string {
+	return terminalError.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (terminalError *TerminalError) Unwrap() error {
+	return terminalError.err
+}
+
+// IsTerminalError checks to see if the error is a terminal error.
+func IsTerminalError(err error) bool {
+	var terminalError *TerminalError
+
+	return errors.As(err, &terminalError)
+}
@@ -0,0 +1,56 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+)
+
+// Finalizer is the finalizer which is set on a component so that the deletion policy of the
+// component may be applied to its child resources before the component is removed.
+const Finalizer = "tenancy.platform.cnr.vmware.com/finalizer"
+
+// FinalizerPhase.DefaultRequeue executes checking for a parent components readiness status.
+func (phase *FinalizerPhase) DefaultRequeue() ctrl.Result {
+	return Requeue()
+}
+
+// FinalizerPhase.Execute executes registering the finalizer on a component prior to attempting
+// resource creation, so that child resources are never created without a finalizer in place.
+func (phase *FinalizerPhase) Execute(
+	r common.ComponentReconciler,
+) (proceedToNextPhase bool, err error) {
+	component, err := componentObject(r)
+	if err != nil {
+		return false, err
+	}
+
+	if controllerutil.ContainsFinalizer(component, Finalizer) {
+		return true, nil
+	}
+
+	controllerutil.AddFinalizer(component, Finalizer)
+
+	if err := r.Update(r.GetContext(), component); err != nil {
+		return false, fmt.Errorf("unable to add finalizer %s; %w", Finalizer, err)
+	}
+
+	return true, nil
+}
+
+// componentObject returns the component of a reconciler as a client object.
+func componentObject(r common.ComponentReconciler) (client.Object, error) {
+	component, ok := r.GetComponent().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("expected component to be a client object; found %T", r.GetComponent())
+	}
+
+	return component, nil
+}
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
 )
 
 const optimisticLockErrorMsg = "the object has been modified; please apply your changes to the latest version and try again"
@@ -51,6 +54,24 @@ func updateResourceConditions(
 	return r.UpdateStatus()
 }
 
+// recordPhaseEvent records an event when a phase completes or fails.  Events are only recorded when
+// the condition of the phase changes, so that requeuing a phase does not record the same event.
+func recordPhaseEvent(r common.ComponentReconciler, condition common.PhaseCondition) {
+	if found := condition.GetPhaseConditionIndex(r.GetComponent()); found >= 0 {
+		previous := r.GetComponent().GetPhaseConditions()[found]
+		if previous.State == condition.State && previous.Message == condition.Message {
+			return
+		}
+	}
+
+	switch condition.State {
+	case common.PhaseStateComplete:
+		resources.RecordEvent(r, corev1.EventTypeNormal, "PhaseCompleted", fmt.Sprintf("completed phase %s", condition.Phase))
+	case common.PhaseStateFailed:
+		resources.RecordEvent(r, corev1.EventTypeWarning, "PhaseFailed", fmt.Sprintf("phase %s failed; %s", condition.Phase, condition.Message))
+	}
+}
+
 // HandlePhaseExit will perform the steps required to exit a phase.
 func HandlePhaseExit(
 	reconciler common.ComponentReconciler,
@@ -79,10 +100,14 @@ func HandlePhaseExit(
 		result = DefaultReconcileResult()
 	}
 
-	// update the status conditions and return any errors
+	recordPhaseEvent(reconciler, condition)
+	setStatusConditions(reconciler, phase, condition, IsTerminalError(phaseError))
+
+	// update the status conditions and return any errors; the component may no longer exist once
+	// it has been deleted, in which case there is no status to update
 	if updateError := updatePhaseConditions(reconciler, &condition); updateError != nil {
 		// adjust the message if we had both an update error and a phase error
-		if !IsOptimisticLockError(updateError) {
+		if !IsOptimisticLockError(updateError) && !errors.IsNotFound(updateError) {
 			if phaseError != nil {
 				phaseError = fmt.Errorf("failed to update status conditions; %v; %v", updateError, phaseError)
 			} else {
@@ -4,7 +4,15 @@
 package phases
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 )
@@ -15,8 +23,86 @@ func (phase *PreFlightPhase) DefaultRequeue() ctrl.Result {
 }
 
 // PreFlightPhase.Execute executes pre-flight and fail-fast conditions prior to attempting resource creation.
+// Each resource which already exists in the cluster, but is not controlled by the component, is
+// checked against the adoption policy of the component.  Conflicts are recorded on the resource
+// conditions and fail the phase before any resource is modified.  Shared resources are never
+// adopted and are therefore not checked.
 func (phase *PreFlightPhase) Execute(
 	r common.ComponentReconciler,
 ) (proceedToNextPhase bool, err error) {
+	component, err := componentObject(r)
+	if err != nil {
+		return false, err
+	}
+
+	var conflicts []string
+
+	for _, resource := range r.GetResources() {
+		if resource.IsShared() {
+			continue
+		}
+
+		conflict, err := adoptionConflict(r, component, resource)
+		if err != nil {
+			return false, err
+		}
+
+		if conflict == "" {
+			continue
+		}
+
+		commonResource := resource.ToCommonResource()
+		commonResource.ResourceCondition = common.ResourceCondition{
+			LastModified: time.Now().UTC().String(),
+			Message:      "unable to adopt resource; " + conflict,
+		}
+		r.GetComponent().SetResource(*commonResource)
+
+		conflicts = append(conflicts, fmt.Sprintf("%s %s: %s", resource.GetKind(), resource.GetName(), conflict))
+	}
+
+	if len(conflicts) > 0 {
+		return false, NewTerminalError(fmt.Errorf("unable to adopt existing resources; %s", strings.Join(conflicts, "; ")))
+	}
+
 	return true, nil
 }
+
+// adoptionConflict returns a message describing why an existing resource may not be adopted by
+// the component, or an empty message if the resource does not exist, is already controlled by the
+// component or may be adopted.
+func adoptionConflict(
+	r common.ComponentReconciler,
+	component client.Object,
+	resource common.ComponentResource,
+) (string, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(resource.GetObject().GetObjectKind().GroupVersionKind())
+
+	if err := r.Get(r.GetContext(), client.ObjectKeyFromObject(resource.GetObject()), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("unable to get existing resource %s %s; %w", resource.GetKind(), resource.GetName(), err)
+	}
+
+	controllerReference := metav1.GetControllerOf(existing)
+	if controllerReference != nil && controllerReference.UID == component.GetUID() {
+		return "", nil
+	}
+
+	policy := r.GetComponent().GetAdoptionPolicy()
+
+	switch {
+	case policy == common.AdoptionPolicyForce:
+		return "", nil
+	case controllerReference != nil:
+		return fmt.Sprintf("resource is controlled by %s %s and adoption policy is %s",
+			controllerReference.Kind, controllerReference.Name, policy), nil
+	case policy == common.AdoptionPolicyIfUnowned:
+		return "", nil
+	default:
+		return fmt.Sprintf("resource already exists and adoption policy is %s", policy), nil
+	}
+}
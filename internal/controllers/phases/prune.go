@@ -0,0 +1,118 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
+)
+
+// pruneResources deletes the resources which are recorded in the status of a component but which
+// are no longer desired, and removes them from the status.  Resources which are not controlled by
+// the component, such as resources which were retained or adopted without being controlled, are
+// removed from the status but are not deleted.
+func pruneResources(r common.ComponentReconciler) error {
+	component, err := componentObject(r)
+	if err != nil {
+		return err
+	}
+
+	var pruned []common.Resource
+
+	for _, resource := range r.GetComponent().GetResources() {
+		if !isDesired(r, resource) {
+			pruned = append(pruned, resource)
+		}
+	}
+
+	for _, resource := range pruned {
+		if err := pruneResource(r, component, resource); err != nil {
+			return err
+		}
+
+		r.GetComponent().RemoveResource(resource)
+	}
+
+	return nil
+}
+
+// isDesired returns whether a resource recorded in the status of a component is one of the
+// desired resources of the reconcile request.
+func isDesired(r common.ComponentReconciler, resource common.Resource) bool {
+	for _, desired := range r.GetResources() {
+		if desired.GetGroup() == resource.Group &&
+			desired.GetVersion() == resource.Version &&
+			desired.GetKind() == resource.Kind &&
+			desired.GetName() == resource.Name &&
+			desired.GetNamespace() == resource.Namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pruneResource deletes a resource which is no longer desired if it is controlled by the
+// component.
+func pruneResource(r common.ComponentReconciler, component client.Object, resource common.Resource) error {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   resource.Group,
+		Version: resource.Version,
+		Kind:    resource.Kind,
+	})
+
+	if err := r.Get(
+		r.GetContext(),
+		types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace},
+		object,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to get resource %s %s for pruning; %w", resource.Kind, resource.Name, err)
+	}
+
+	if !isControlledBy(object, component) {
+		r.GetLogger().V(0).Info(fmt.Sprintf("resource is not controlled by component; skipping prune of kind: [%s], name: [%s], namespace: [%s]",
+			resource.Kind, resource.Name, resource.Namespace))
+
+		return nil
+	}
+
+	r.GetLogger().V(0).Info(fmt.Sprintf("pruning resource; kind: [%s], name: [%s], namespace: [%s]",
+		resource.Kind, resource.Name, resource.Namespace))
+
+	if err := r.Delete(r.GetContext(), object, client.PropagationPolicy("Background")); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("unable to prune resource %s %s; %w", resource.Kind, resource.Name, err)
+	}
+
+	metrics.CountResourceOperation(resource.Kind, metrics.ResourceOperationPrune)
+	resources.RecordEvent(r, corev1.EventTypeNormal, "ResourcePruned",
+		fmt.Sprintf("pruned resource %s %s which is no longer desired", resource.Kind, resource.Name))
+
+	return nil
+}
+
+// isControlledBy returns whether an object has a controller reference to the component.
+func isControlledBy(object, component client.Object) bool {
+	for _, ownerReference := range object.GetOwnerReferences() {
+		if ownerReference.UID == component.GetUID() && ownerReference.Controller != nil && *ownerReference.Controller {
+			return true
+		}
+	}
+
+	return false
+}
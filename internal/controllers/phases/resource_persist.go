@@ -4,11 +4,15 @@
 package phases
 
 import (
+	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
 )
 
 // PersistResourcePhase.Execute executes persisting resources to the Kubernetes database.
@@ -34,8 +38,29 @@ func persistResource(
 	condition common.ResourceCondition,
 	phase *PersistResourcePhase,
 ) error {
-	// persist resource
 	r := resource.GetReconciler()
+
+	if resource.IsShared() {
+		return persistSharedResource(resource)
+	}
+
+	// detect whether the resource has drifted from its desired state since it was last persisted
+	var previous *common.Resource
+	if found := resource.ToCommonResource().GetResourceIndex(r.GetComponent()); found >= 0 {
+		previous = &r.GetComponent().GetResources()[found]
+		condition.Drift = previous.Drift
+	}
+
+	drift, err := resources.DetectDrift(resource, previous)
+	if err != nil {
+		return err
+	}
+
+	if drift != nil {
+		return handleDrift(resource, condition, phase, drift)
+	}
+
+	// persist resource
 	if err := r.CreateOrUpdate(resource.GetObject()); err != nil {
 		if IsOptimisticLockError(err) {
 			return nil
@@ -55,3 +80,67 @@ func persistResource(
 	// update the condition to notify that we have created a child resource
 	return updateResourceConditions(r, *resource.ToCommonResource(), &condition)
 }
+
+// persistSharedResource persists a shared resource, which exists independently of the component.
+// Shared resources are not recorded in the status of the component, so that they are never pruned
+// or deleted along with the component.
+func persistSharedResource(resource common.ComponentResource) error {
+	r := resource.GetReconciler()
+
+	if err := r.CreateOrUpdate(resource.GetObject()); err != nil {
+		if IsOptimisticLockError(err) {
+			return nil
+		}
+
+		r.GetLogger().V(0).Info(err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// handleDrift reports the drift of a resource and, when the drift policy of the component is
+// Enforce, restores the resource to its desired state.
+func handleDrift(
+	resource common.ComponentResource,
+	condition common.ResourceCondition,
+	phase *PersistResourcePhase,
+	drift *resources.Drift,
+) error {
+	r := resource.GetReconciler()
+	policy := r.GetComponent().GetDriftPolicy()
+
+	metrics.CountResourceOperation(resource.GetKind(), metrics.ResourceOperationDrift)
+
+	condition.LastResourcePhase = getResourcePhaseName(phase)
+	condition.LastModified = time.Now().UTC().String()
+	condition.Created = true
+	condition.Drift = fmt.Sprintf("%s; detected at %s", drift, condition.LastModified)
+
+	if policy == common.DriftPolicyReport {
+		condition.Drifted = true
+		condition.Message = fmt.Sprintf("resource has drifted; not restored as drift policy is %s", policy)
+
+		resources.RecordEvent(r, corev1.EventTypeWarning, "ResourceDrifted",
+			fmt.Sprintf("%s %s has drifted; %s", resource.GetKind(), resource.GetName(), drift))
+
+		return updateResourceConditions(r, *resource.ToCommonResource(), &condition)
+	}
+
+	if err := r.CreateOrUpdate(resource.GetObject()); err != nil {
+		if IsOptimisticLockError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	condition.Drifted = false
+	condition.Message = "resource restored after drift"
+
+	resources.RecordEvent(r, corev1.EventTypeWarning, "ResourceDriftCorrected",
+		fmt.Sprintf("%s %s has been restored; %s", resource.GetKind(), resource.GetName(), drift))
+
+	return updateResourceConditions(r, *resource.ToCommonResource(), &condition)
+}
@@ -0,0 +1,67 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package phases
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
+)
+
+// Below are the reasons of the Suspended condition.
+const (
+	reasonSuspended = "Suspended"
+	reasonResumed   = "Resumed"
+)
+
+// HandleSuspension sets the Suspended condition of a component and returns whether the
+// reconciliation of the component is suspended, in which case none of the phases are executed.
+// The status of the component is only updated when the component is suspended or resumed, so that
+// reconcile requests for a suspended component, such as those which are triggered by the watches
+// on its child resources, do not modify the component.
+func HandleSuspension(r common.ComponentReconciler) (bool, error) {
+	suspended := r.GetComponent().IsSuspended()
+
+	condition := metav1.Condition{
+		Type:    common.ConditionTypeSuspended,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonResumed,
+		Message: "reconciliation has resumed",
+	}
+
+	if suspended {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonSuspended
+		condition.Message = "reconciliation is suspended"
+	}
+
+	existing := meta.FindStatusCondition(r.GetComponent().GetStatusConditions(), common.ConditionTypeSuspended)
+
+	// the condition is only reported once a component has been suspended
+	if existing == nil && !suspended {
+		return false, nil
+	}
+
+	if existing != nil && existing.Status == condition.Status {
+		return suspended, nil
+	}
+
+	if component, err := componentObject(r); err == nil {
+		condition.ObservedGeneration = component.GetGeneration()
+	}
+
+	r.GetComponent().SetStatusCondition(condition)
+	resources.RecordEvent(r, corev1.EventTypeNormal, condition.Reason, condition.Message)
+	r.GetLogger().V(0).Info(condition.Message)
+
+	if err := r.UpdateStatus(); err != nil && !errors.IsNotFound(err) {
+		return suspended, err
+	}
+
+	return suspended, nil
+}
@@ -30,6 +30,8 @@ type PreFlightPhase struct{}
 type CreateResourcesPhase struct{}
 type CheckReadyPhase struct{}
 type CompletePhase struct{}
+type FinalizerPhase struct{}
+type DeletionPhase struct{}
 
 // Below are the phase types which satisfy the ResourcePhase interface.
 type PersistResourcePhase struct{}
@@ -38,7 +40,7 @@ type WaitForResourcePhase struct{}
 // GetSuccessCondition defines the success condition for the phase.
 func GetSuccessCondition(phase Phase) common.PhaseCondition {
 	return common.PhaseCondition{
-		Phase:   getPhaseName(phase),
+		Phase:   GetPhaseName(phase),
 		State:   common.PhaseStateComplete,
 		Message: "Successfully Completed Phase",
 	}
@@ -47,7 +49,7 @@ func GetSuccessCondition(phase Phase) common.PhaseCondition {
 // GetPendingCondition defines the pending condition for the phase.
 func GetPendingCondition(phase Phase) common.PhaseCondition {
 	return common.PhaseCondition{
-		Phase:   getPhaseName(phase),
+		Phase:   GetPhaseName(phase),
 		State:   common.PhaseStatePending,
 		Message: "Pending Execution of Phase",
 	}
@@ -56,13 +58,14 @@ func GetPendingCondition(phase Phase) common.PhaseCondition {
 // GetFailCondition defines the fail condition for the phase.
 func GetFailCondition(phase Phase, err error) common.PhaseCondition {
 	return common.PhaseCondition{
-		Phase:   getPhaseName(phase),
+		Phase:   GetPhaseName(phase),
 		State:   common.PhaseStateFailed,
 		Message: "Failed Phase with Error; " + err.Error(),
 	}
 }
 
-func getPhaseName(phase Phase) string {
+// GetPhaseName returns the name of a phase, which is the name of its type.
+func GetPhaseName(phase Phase) string {
 	objectElements := strings.Split(fmt.Sprintf("%s", reflect.TypeOf(phase)), ".")
 
 	return objectElements[len(objectElements)-1]
@@ -0,0 +1,92 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// dedupingRecorder is an event recorder which only records an identical event for the same object
+// once within a window, so that requeue loops which repeatedly reach the same outcome do not
+// flood the events of an object.
+type dedupingRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+
+	recordedLock sync.Mutex
+	recorded     map[string]time.Time
+}
+
+// NewDedupingRecorder returns an event recorder which wraps a recorder so that an identical event
+// for the same object is only recorded once within the window.
+func NewDedupingRecorder(recorder record.EventRecorder, window time.Duration) record.EventRecorder {
+	return &dedupingRecorder{
+		recorder: recorder,
+		window:   window,
+		recorded: map[string]time.Time{},
+	}
+}
+
+// Event records an event unless an identical event has been recorded within the window.
+func (r *dedupingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// Eventf records an event, with a formatted message, unless an identical event has been recorded
+// within the window.
+func (r *dedupingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf records an event, with annotations and a formatted message, unless an identical
+// event has been recorded within the window.
+func (r *dedupingRecorder) AnnotatedEventf(
+	object runtime.Object,
+	annotations map[string]string,
+	eventtype, reason, messageFmt string,
+	args ...interface{},
+) {
+	message := fmt.Sprintf(messageFmt, args...)
+
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// shouldRecord returns whether an event has not been recorded within the window and stores the
+// time at which it is recorded.  Entries which have expired are removed.
+func (r *dedupingRecorder) shouldRecord(object runtime.Object, eventtype, reason, message string) bool {
+	var uid string
+	if accessor, err := meta.Accessor(object); err == nil {
+		uid = string(accessor.GetUID())
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s", uid, eventtype, reason, message)
+	now := time.Now()
+
+	r.recordedLock.Lock()
+	defer r.recordedLock.Unlock()
+
+	for recordedKey, recordedTime := range r.recorded {
+		if now.Sub(recordedTime) > r.window {
+			delete(r.recorded, recordedKey)
+		}
+	}
+
+	if _, found := r.recorded[key]; found {
+		return false
+	}
+
+	r.recorded[key] = now
+
+	return true
+}
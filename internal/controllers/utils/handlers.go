@@ -0,0 +1,45 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EnqueueClusterControllerAfter returns an event handler which enqueues a request for the cluster
+// scoped controller of an updated object, given the group and kind of the controller, once a delay
+// has passed.  Requests for the same controller which are enqueued within the delay are coalesced
+// into a single request, which limits the rate at which a frequently updated object is reconciled.
+func EnqueueClusterControllerAfter(controllerKind schema.GroupKind, delay time.Duration) handler.EventHandler {
+	enqueue := func(object client.Object, queue workqueue.RateLimitingInterface) {
+		controllerReference := metav1.GetControllerOf(object)
+		if controllerReference == nil || controllerReference.Kind != controllerKind.Kind {
+			return
+		}
+
+		groupVersion, err := schema.ParseGroupVersion(controllerReference.APIVersion)
+		if err != nil || groupVersion.Group != controllerKind.Group {
+			return
+		}
+
+		queue.AddAfter(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: controllerReference.Name},
+		}, delay)
+	}
+
+	return handler.Funcs{
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(e.ObjectNew, queue)
+		},
+	}
+}
@@ -5,10 +5,12 @@ package utils
 
 import (
 	"reflect"
+	"sync"
 
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -24,6 +26,11 @@ const (
 	FieldManager = "reconciler"
 )
 
+var (
+	predicateLog = ctrl.Log.WithName("predicates")
+	watchLock    sync.Mutex
+)
+
 func IgnoreNotFound(err error) error {
 	if apierrs.IsNotFound(err) {
 		return nil
@@ -37,6 +44,7 @@ func CreatePhases() []controllerphases.Phase {
 	return []controllerphases.Phase{
 		&controllerphases.DependencyPhase{},
 		&controllerphases.PreFlightPhase{},
+		&controllerphases.FinalizerPhase{},
 		&controllerphases.CreateResourcesPhase{},
 		&controllerphases.CheckReadyPhase{},
 		&controllerphases.CompletePhase{},
@@ -49,9 +57,21 @@ func UpdatePhases() []controllerphases.Phase {
 	return CreatePhases()
 }
 
+// DeletePhases defines the phases for deletion and the order in which they run during the reconcile process.
+func DeletePhases() []controllerphases.Phase {
+	return []controllerphases.Phase{
+		&controllerphases.DeletionPhase{},
+	}
+}
+
 // Phases returns which phases to run given the component.
 func Phases(component common.Component) []controllerphases.Phase {
 	var phases []controllerphases.Phase
+
+	if object, ok := component.(client.Object); ok && !object.GetDeletionTimestamp().IsZero() {
+		return DeletePhases()
+	}
+
 	if !component.GetReadyStatus() {
 		phases = CreatePhases()
 	} else {
@@ -61,20 +81,16 @@ func Phases(component common.Component) []controllerphases.Phase {
 	return phases
 }
 
-// getDesiredObject returns the desired object from a list stored on the
-// reconciler.
-func getDesiredObject(compared *resources.Resource) (desired *resources.Resource) {
-	for _, resource := range compared.Reconciler.GetResources() {
-		if resource.EqualGVK(compared) && resource.EqualNamespaceName(compared) {
-			return resource.(*resources.Resource)
-		}
-	}
-
-	return desired
-}
-
 // needsReconciliation performs some simple checks and returns whether or not a
-// resource needs to be updated.
+// resource needs to be updated.  The checks compare the old and new versions of the
+// object from the event rather than the desired and new versions, as the desired
+// resources belong to an individual reconcile request and may not be shared with the
+// watch without shared state between concurrent requests.  Comparing the old and new
+// versions is enough to detect drift: the old version either matches the desired
+// resource or was itself the result of an event which was reconciled, so any change
+// to, or removal of, a desired field also differs from the old version.  The cost is
+// an additional reconciliation after each update of the resource by the operator,
+// which finds no drift and makes no changes.
 func needsReconciliation(existing, requested resources.Resource) bool {
 	// skip if the resources versions are the same
 	if existing.Object.GetResourceVersion() == requested.Object.GetResourceVersion() {
@@ -88,33 +104,45 @@ func needsReconciliation(existing, requested resources.Resource) bool {
 		}
 	}
 
-	// get the desired object from the reconciler and ensure that we both
-	// found that desired object and that the desired object fields are equal
-	// to the existing object fields
-	desired := getDesiredObject(&requested)
-	if desired == nil {
+	// ensure that the fields of the existing object are equal to the fields of
+	// the requested object, ignoring fields such as status
+	equal, err := resources.AreEqual(existing, requested)
+	if err != nil {
+		predicateLog.V(0).Error(err, "unable to determine equality for reconciliation")
+
 		return true
 	}
 
-	equal, err := resources.AreEqual(*desired, requested)
+	return !equal
+}
+
+// statusChanged returns whether the status of an object differs between two versions of it.
+func statusChanged(existing, requested client.Object) bool {
+	existingContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
 	if err != nil {
-		requested.Reconciler.GetLogger().V(0).Error(err,
-			"unable to determine equality for reconciliation")
+		predicateLog.V(0).Error(err, "unable to determine status for reconciliation")
 
 		return true
 	}
 
-	return !equal
+	requestedContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(requested)
+	if err != nil {
+		predicateLog.V(0).Error(err, "unable to determine status for reconciliation")
+
+		return true
+	}
+
+	return !reflect.DeepEqual(existingContent["status"], requestedContent["status"])
 }
 
 // ResourcePredicates returns the filters which are used to filter out the common reconcile events
 // prior to reconciling the child resource of a component.
-func ResourcePredicates(r common.ComponentReconciler) predicate.Predicate {
+func ResourcePredicates() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			return needsReconciliation(
-				*resources.NewResourceFromClient(e.ObjectOld, r),
-				*resources.NewResourceFromClient(e.ObjectNew, r),
+				*resources.NewResourceFromClient(e.ObjectOld),
+				*resources.NewResourceFromClient(e.ObjectNew),
 			)
 		},
 		GenericFunc: func(e event.GenericEvent) bool {
@@ -125,6 +153,30 @@ func ResourcePredicates(r common.ComponentReconciler) predicate.Predicate {
 			// do not run reconciliation again when we just created the child resource
 			return false
 		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// always run reconciliation when a child resource is deleted so that the deletion is
+			// reported as drift and, depending upon the drift policy, the child resource is restored
+			return true
+		},
+	}
+}
+
+// StatusPredicates returns the filters which are used to filter out the reconcile events of a child
+// resource whose status has not changed, so that only changes to its status are reconciled.
+func StatusPredicates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return statusChanged(e.ObjectOld, e.ObjectNew)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
 	}
 }
 
@@ -133,7 +185,10 @@ func ResourcePredicates(r common.ComponentReconciler) predicate.Predicate {
 func ComponentPredicates() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+			// the suspend annotation does not change the generation, so changes to it are checked
+			// explicitly in order to suspend or resume reconciliation
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				e.ObjectOld.GetAnnotations()[common.SuspendAnnotation] != e.ObjectNew.GetAnnotations()[common.SuspendAnnotation]
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return true
@@ -147,11 +202,15 @@ func ComponentPredicates() predicate.Predicate {
 	}
 }
 
-// Watch watches a resource.
+// Watch watches a resource.  Watches are registered on the controller, which is shared between
+// concurrent reconcile requests, so registration is serialized to avoid watching a kind twice.
 func Watch(
 	r common.ComponentReconciler,
 	resource client.Object,
 ) error {
+	watchLock.Lock()
+	defer watchLock.Unlock()
+
 	// check if the resource is already being watched
 	var watched bool
 
@@ -174,7 +233,7 @@ func Watch(
 				IsController: true,
 				OwnerType:    r.GetComponent().(runtime.Object),
 			},
-			ResourcePredicates(r),
+			ResourcePredicates(),
 		); err != nil {
 			return err
 		}
@@ -0,0 +1,136 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+)
+
+// SetResourceQuotaStatus refreshes the status.resourceQuotas and status.quotaUsage fields of a
+// TanzuNamespace from the status of each of its ResourceQuotas.  ResourceQuotas which do not yet
+// exist are omitted.  Only the component in memory is modified; persisting the status is left to
+// the caller.
+func SetResourceQuotaStatus(
+	ctx context.Context,
+	reader client.Reader,
+	component *tenancyv1alpha2.TanzuNamespace,
+) error {
+	names := []string{tenancyv1alpha2.ResourceQuotaName}
+	for i := range component.Spec.Resources.ScopedQuotas {
+		names = append(names, component.Spec.Resources.ScopedQuotas[i].GetResourceQuotaName())
+	}
+
+	var statuses []tenancyv1alpha2.TanzuNamespaceStatusResourceQuota
+
+	var quotaUsage *tenancyv1alpha2.TanzuNamespaceStatusQuotaUsage
+
+	for _, name := range names {
+		quota := &corev1.ResourceQuota{}
+
+		if err := reader.Get(
+			ctx,
+			client.ObjectKey{Name: name, Namespace: component.Spec.Namespace},
+			quota,
+		); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if name == tenancyv1alpha2.ResourceQuotaName {
+			quotaUsage = newQuotaUsage(quota)
+		}
+
+		statuses = append(statuses, tenancyv1alpha2.TanzuNamespaceStatusResourceQuota{
+			Name:      name,
+			Hard:      quota.Status.Hard,
+			Used:      quota.Status.Used,
+			Exhausted: exhaustedResources(quota),
+		})
+	}
+
+	component.Status.ResourceQuotas = statuses
+	component.Status.QuotaUsage = quotaUsage
+
+	return nil
+}
+
+// exhaustedResources returns the resources of a ResourceQuota whose usage has reached their hard
+// limit, in order of their names.  Resources with a hard limit of zero are forbidden rather than
+// exhausted, so they are omitted.
+func exhaustedResources(quota *corev1.ResourceQuota) []string {
+	var exhausted []string
+
+	for resource, hard := range quota.Status.Hard {
+		used, found := quota.Status.Used[resource]
+		if found && !hard.IsZero() && used.Cmp(hard) >= 0 {
+			exhausted = append(exhausted, string(resource))
+		}
+	}
+
+	sort.Strings(exhausted)
+
+	return exhausted
+}
+
+// newQuotaUsage returns the utilization of each of the resources of a ResourceQuota, in order of
+// their names, along with the utilization of its cpu and memory resources.
+func newQuotaUsage(quota *corev1.ResourceQuota) *tenancyv1alpha2.TanzuNamespaceStatusQuotaUsage {
+	quotaUsage := &tenancyv1alpha2.TanzuNamespaceStatusQuotaUsage{}
+
+	utilizations := map[string]int64{}
+
+	for name, hard := range quota.Status.Hard {
+		usage := tenancyv1alpha2.TanzuNamespaceStatusQuotaUsageResource{
+			Name: string(name),
+			Used: quota.Status.Used[name],
+			Hard: hard,
+		}
+
+		if !hard.IsZero() {
+			utilization := int64(usage.Used.AsApproximateFloat64() / hard.AsApproximateFloat64() * 100)
+			usage.Utilization = &utilization
+			utilizations[string(name)] = utilization
+		}
+
+		quotaUsage.Resources = append(quotaUsage.Resources, usage)
+	}
+
+	sort.Slice(quotaUsage.Resources, func(i, j int) bool {
+		return quotaUsage.Resources[i].Name < quotaUsage.Resources[j].Name
+	})
+
+	quotaUsage.CPU = highestUtilization(utilizations, "requests.cpu", "limits.cpu")
+	quotaUsage.Memory = highestUtilization(utilizations, "requests.memory", "limits.memory")
+
+	return quotaUsage
+}
+
+// highestUtilization returns the highest utilization of a set of resources as a percentage, or an
+// empty string if none of the resources have a utilization.
+func highestUtilization(utilizations map[string]int64, names ...string) string {
+	highest, found := int64(0), false
+
+	for _, name := range names {
+		if utilization, ok := utilizations[name]; ok && (!found || utilization > highest) {
+			highest, found = utilization, true
+		}
+	}
+
+	if !found {
+		return ""
+	}
+
+	return fmt.Sprintf("%d%%", highest)
+}
@@ -4,10 +4,41 @@
 package dependencies
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/helpers"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
 )
 
 // TanzuNamespaceCheckReady performs the logic to determine if a TanzuNamespace object is ready.
 func TanzuNamespaceCheckReady(reconciler common.ComponentReconciler) (bool, error) {
+	component, err := helpers.TanzuNamespaceFromReconciler(reconciler)
+	if err != nil {
+		return false, err
+	}
+
+	// refresh the usage of the resource quotas so that it is reported on each reconciliation
+	if err := SetResourceQuotaStatus(reconciler.GetContext(), reconciler.GetClient(), component); err != nil {
+		return false, err
+	}
+
+	// image pull secrets are not ready until they have been copied from their source secrets
+	for _, imagePullSecret := range component.Spec.ImagePullSecrets {
+		secret := resources.NewResourceFromClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      imagePullSecret.Name,
+				Namespace: component.Spec.Namespace,
+			},
+		}, reconciler)
+
+		ready, err := resources.SecretIsReady(secret, tanzunamespace.ImagePullSecretKey)
+		if !ready || err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
@@ -4,23 +4,17 @@
 package helpers
 
 import (
-	"errors"
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 )
 
 const (
-	Domain               = "platform.cnr.vmware.com"
-	CollectionAPIGroup   = "tenancy"
-	CollectionAPIVersion = "v1alpha2"
-	CollectionAPIKind    = "TanzuNamespace"
+	Domain = "platform.cnr.vmware.com"
 )
 
 // SkipResourceCreation skips the resource creation during the mutate phase.
@@ -42,52 +36,25 @@ func GetProperObject(destination metav1.Object, source metav1.Object) error {
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObject, destination)
 }
 
-// getValueFromCollection gets a specific value from the TanzuNamespace resource.
-func getValueFromCollection(reconciler common.ComponentReconciler, path ...string) (string, error) {
-	// retrieve a list of platform configs
-	collectionConfigs, err := GetCollectionConfigs(reconciler)
+// getValueFromComponent gets a specific value from the component which is currently being
+// reconciled.
+func getValueFromComponent(reconciler common.ComponentReconciler, path ...string) (string, error) {
+	// convert the component to an unstructured type so that we may look up an arbitrary path
+	component, err := runtime.DefaultUnstructuredConverter.ToUnstructured(reconciler.GetComponent())
 	if err != nil {
 		return "", err
 	}
 
-	if len(collectionConfigs.Items) > 1 {
-		return "", errors.New("Must have only one collection resource present in the cluster")
-	}
-
-	// get the first platform config
-	collectionConfig := collectionConfigs.Items[0]
-
-	// get the value from the platform config
-	collectionConfigValue, found, err := unstructured.NestedString(collectionConfig.Object, path...)
+	// get the value from the component
+	componentValue, found, err := unstructured.NestedString(component, path...)
 	if !found || err != nil {
-		return "", fmt.Errorf("unable to get path %s from platform configuration; %v\n", path, err)
-	}
-
-	return collectionConfigValue, nil
-}
-
-// GetCollectionConfigs returns all of the collection resources from the cluster.
-func GetCollectionConfigs(
-	r common.ComponentReconciler,
-) (unstructured.UnstructuredList, error) {
-	collectionConfigs := unstructured.UnstructuredList{}
-	collectionConfigGVK := schema.GroupVersionKind{
-		Group:   fmt.Sprintf("%s.%s", CollectionAPIGroup, Domain),
-		Version: CollectionAPIVersion,
-		Kind:    CollectionAPIKind,
-	}
-
-	// get a list of configurations from the cluster
-	collectionConfigs.SetGroupVersionKind(collectionConfigGVK)
-
-	if err := r.List(r.GetContext(), &collectionConfigs, &client.ListOptions{}); err != nil {
-		return collectionConfigs, err
+		return "", fmt.Errorf("unable to get path %s from component; %v", path, err)
 	}
 
-	return collectionConfigs, nil
+	return componentValue, nil
 }
 
-// GetCollectionName returns the name of the platform from the TanzuNamespace resource.
-func GetCollectionName(r common.ComponentReconciler) (string, error) {
-	return getValueFromCollection(r, "metadata", "name")
+// GetComponentName returns the name of the component which is currently being reconciled.
+func GetComponentName(r common.ComponentReconciler) (string, error) {
+	return getValueFromComponent(r, "metadata", "name")
 }
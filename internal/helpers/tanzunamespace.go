@@ -10,25 +10,20 @@ import (
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
 )
 
-// TanzuNamespaceUnique returns only one TanzuNamespace and returns an error if more than one are found.
-func TanzuNamespaceUnique(
+// TanzuNamespaceFromReconciler returns the TanzuNamespace which is currently being reconciled and
+// returns an error if the reconciler is operating against a different kind of component.
+func TanzuNamespaceFromReconciler(
 	reconciler common.ComponentReconciler,
 ) (
 	*tenancyv1alpha2.TanzuNamespace,
 	error,
 ) {
-	components, err := TanzuNamespaceList(reconciler)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(components.Items) != 1 {
-		return nil, fmt.Errorf("expected only 1 TanzuNamespace; found %v\n", len(components.Items))
+	component, ok := reconciler.GetComponent().(*tenancyv1alpha2.TanzuNamespace)
+	if !ok {
+		return nil, fmt.Errorf("expected component of kind TanzuNamespace; found %T", reconciler.GetComponent())
 	}
 
-	component := components.Items[0]
-
-	return &component, nil
+	return component, nil
 }
 
 // TanzuNamespaceList gets a TanzuNamespaceList from the cluster.
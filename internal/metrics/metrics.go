@@ -0,0 +1,79 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace is the namespace of each of the metrics exposed by the operator.
+const Namespace = "tanzunamespace"
+
+// Below are the operations which are counted against the child resources of a component.
+const (
+	ResourceOperationCreate = "create"
+	ResourceOperationUpdate = "update"
+	ResourceOperationDrift  = "drift"
+	ResourceOperationPrune  = "prune"
+)
+
+var (
+	// PhaseDuration observes the duration of each phase, labelled by the name of the phase.
+	PhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "phase_duration_seconds",
+			Help:      "Duration of the execution of each reconciliation phase.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"phase"},
+	)
+
+	// PhaseFailures counts the failures of each phase, labelled by the name of the phase.
+	PhaseFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "phase_failures_total",
+			Help:      "Number of failed executions of each reconciliation phase.",
+		},
+		[]string{"phase"},
+	)
+
+	// ResourceOperations counts the operations against child resources, labelled by the kind of
+	// the child resource and the operation.
+	ResourceOperations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "resource_operations_total",
+			Help:      "Number of create, update, drift and prune operations against child resources.",
+		},
+		[]string{"kind", "operation"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		PhaseDuration,
+		PhaseFailures,
+		ResourceOperations,
+	)
+}
+
+// ObservePhase records the duration of a phase which started at a given time, along with a
+// failure if the phase returned an error.
+func ObservePhase(phase string, start time.Time, err error) {
+	PhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		PhaseFailures.WithLabelValues(phase).Inc()
+	}
+}
+
+// CountResourceOperation counts an operation against a child resource of a given kind.
+func CountResourceOperation(kind, operation string) {
+	ResourceOperations.WithLabelValues(kind, operation).Inc()
+}
@@ -4,14 +4,201 @@
 package mutate
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
 )
 
+// ImagePullSecretSourceNamespace is the namespace which stores the source secrets that image pull
+// secrets are copied from.  It is set from the command line of the operator.
+var ImagePullSecretSourceNamespace = "namespace-operator-system"
+
 // TanzuNamespaceMutate performs the logic to mutate resources that belong to the parent.
 func TanzuNamespaceMutate(reconciler common.ComponentReconciler,
 	object *metav1.Object,
 ) (replacedObjects []metav1.Object, skip bool, err error) {
+	resourceObj, ok := (*object).(*unstructured.Unstructured)
+	if !ok {
+		return []metav1.Object{*object}, false, nil
+	}
+
+	switch {
+	case resourceObj.GetKind() == "Secret" && resourceObj.GetAnnotations()[tanzunamespace.ImagePullSecretSourceAnnotation] != "":
+		return mutateImagePullSecret(reconciler, resourceObj)
+	case resourceObj.GetKind() == "ServiceAccount" && hasAnnotation(resourceObj, tanzunamespace.ImagePullSecretsAnnotation):
+		return mutateImagePullSecretServiceAccount(reconciler, resourceObj)
+	}
+
 	return []metav1.Object{*object}, false, nil
 }
+
+// mutateImagePullSecret copies the data of the source secret into an image pull secret.  The image
+// pull secret is skipped if the source secret does not yet exist, as a secret of type
+// kubernetes.io/dockerconfigjson may not be created without data.  An image pull secret which has
+// already been copied is kept with its existing data while the source secret is unavailable, so
+// that it is not pruned from the namespace.  The source secret is read directly from the API
+// server so that secrets are not cached outside of the source namespace.
+func mutateImagePullSecret(
+	reconciler common.ComponentReconciler,
+	resourceObj *unstructured.Unstructured,
+) ([]metav1.Object, bool, error) {
+	sourceName := types.NamespacedName{
+		Name:      resourceObj.GetAnnotations()[tanzunamespace.ImagePullSecretSourceAnnotation],
+		Namespace: ImagePullSecretSourceNamespace,
+	}
+
+	source := &corev1.Secret{}
+	if err := reconciler.GetAPIReader().Get(reconciler.GetContext(), sourceName, source); err != nil {
+		if errors.IsNotFound(err) {
+			return keepImagePullSecret(reconciler, resourceObj,
+				fmt.Sprintf("source secret [%s] not found", sourceName))
+		}
+
+		return nil, false, fmt.Errorf("unable to get source secret %s; %w", sourceName, err)
+	}
+
+	if len(source.Data[tanzunamespace.ImagePullSecretKey]) == 0 {
+		return keepImagePullSecret(reconciler, resourceObj,
+			fmt.Sprintf("source secret [%s] is missing key [%s]", sourceName, tanzunamespace.ImagePullSecretKey))
+	}
+
+	if err := unstructured.SetNestedField(resourceObj.Object, map[string]interface{}{
+		tanzunamespace.ImagePullSecretKey: base64.StdEncoding.EncodeToString(source.Data[tanzunamespace.ImagePullSecretKey]),
+	}, "data"); err != nil {
+		return nil, false, err
+	}
+
+	return []metav1.Object{resourceObj}, false, nil
+}
+
+// keepImagePullSecret keeps the data of an existing image pull secret when its source secret is
+// unavailable.  The image pull secret is skipped if it has not yet been copied.
+func keepImagePullSecret(
+	reconciler common.ComponentReconciler,
+	resourceObj *unstructured.Unstructured,
+	reason string,
+) ([]metav1.Object, bool, error) {
+	existing := &corev1.Secret{}
+	if err := reconciler.GetAPIReader().Get(
+		reconciler.GetContext(),
+		types.NamespacedName{Name: resourceObj.GetName(), Namespace: resourceObj.GetNamespace()},
+		existing,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			reconciler.GetLogger().V(0).Info(fmt.Sprintf("skipping image pull secret; %s", reason))
+
+			return nil, true, nil
+		}
+
+		return nil, false, fmt.Errorf("unable to get image pull secret %s/%s; %w",
+			resourceObj.GetNamespace(), resourceObj.GetName(), err)
+	}
+
+	reconciler.GetLogger().V(0).Info(fmt.Sprintf("keeping existing image pull secret [%s/%s]; %s",
+		existing.Namespace, existing.Name, reason))
+
+	data := map[string]interface{}{}
+	for key, value := range existing.Data {
+		data[key] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	if err := unstructured.SetNestedField(resourceObj.Object, data, "data"); err != nil {
+		return nil, false, err
+	}
+
+	return []metav1.Object{resourceObj}, false, nil
+}
+
+// mutateImagePullSecretServiceAccount merges the image pull secrets of the existing default
+// ServiceAccount into the desired ServiceAccount so that image pull secrets which are not managed
+// by the operator are preserved.  Image pull secrets which were previously managed by the operator,
+// but are no longer requested, are removed.  The ServiceAccount is skipped when no image pull
+// secrets are requested and none were previously managed, so that the default ServiceAccount of a
+// namespace without image pull secrets is left untouched.
+func mutateImagePullSecretServiceAccount(
+	reconciler common.ComponentReconciler,
+	resourceObj *unstructured.Unstructured,
+) ([]metav1.Object, bool, error) {
+	existing := &corev1.ServiceAccount{}
+	if err := reconciler.Get(
+		reconciler.GetContext(),
+		types.NamespacedName{Name: resourceObj.GetName(), Namespace: resourceObj.GetNamespace()},
+		existing,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			if resourceObj.GetAnnotations()[tanzunamespace.ImagePullSecretsAnnotation] == "" {
+				return nil, true, nil
+			}
+
+			return []metav1.Object{resourceObj}, false, nil
+		}
+
+		return nil, false, fmt.Errorf("unable to get service account %s/%s; %w",
+			resourceObj.GetNamespace(), resourceObj.GetName(), err)
+	}
+
+	desired := imagePullSecretNames(resourceObj.GetAnnotations()[tanzunamespace.ImagePullSecretsAnnotation])
+	previous := imagePullSecretNames(existing.Annotations[tanzunamespace.ImagePullSecretsAnnotation])
+
+	if len(desired) == 0 && len(previous) == 0 {
+		return nil, true, nil
+	}
+
+	// keep the image pull secrets which are not managed by the operator ahead of the managed secrets
+	imagePullSecrets := []interface{}{}
+
+	for _, imagePullSecret := range existing.ImagePullSecrets {
+		if desired[imagePullSecret.Name] || previous[imagePullSecret.Name] {
+			continue
+		}
+
+		imagePullSecrets = append(imagePullSecrets, map[string]interface{}{
+			"name": imagePullSecret.Name,
+		})
+	}
+
+	managed, _, err := unstructured.NestedSlice(resourceObj.Object, "imagePullSecrets")
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := unstructured.SetNestedSlice(
+		resourceObj.Object,
+		append(imagePullSecrets, managed...),
+		"imagePullSecrets",
+	); err != nil {
+		return nil, false, err
+	}
+
+	return []metav1.Object{resourceObj}, false, nil
+}
+
+// imagePullSecretNames returns the names of the image pull secrets which are stored in the image
+// pull secrets annotation.
+func imagePullSecretNames(value string) map[string]bool {
+	names := map[string]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		if name != "" {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// hasAnnotation returns whether an object has an annotation, regardless of its value.
+func hasAnnotation(object metav1.Object, key string) bool {
+	_, found := object.GetAnnotations()[key]
+
+	return found
+}
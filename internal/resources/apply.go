@@ -0,0 +1,360 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+const (
+	// ApplyFieldManager is the field manager which owns the fields of child resources which are
+	// persisted with server-side apply.
+	ApplyFieldManager = "namespace-operator"
+)
+
+var (
+	// ServerSideApply determines whether child resources are persisted with server-side apply
+	// rather than with a merge patch.
+	ServerSideApply = false
+
+	// ForceApplyConflicts determines whether server-side apply takes ownership of fields which
+	// are owned by another field manager, rather than failing with a conflict.
+	ForceApplyConflicts = false
+)
+
+// Apply persists a resource with server-side apply.  Conflicts which are solely with the field
+// manager of the merge patch, which is used by prior versions of the operator, are always forced
+// so that the ownership of the fields is migrated to the apply field manager.
+func (resource *Resource) Apply() error {
+	resource.Reconciler.GetLogger().V(0).Info(fmt.Sprintf("applying resource; kind: [%s], name: [%s], namespace: [%s]",
+		resource.Kind, resource.Name, resource.Namespace))
+
+	err := resource.apply(ForceApplyConflicts)
+	if errors.IsConflict(err) && !ForceApplyConflicts && conflictsOnlyWith(err, FieldManager) {
+		err = resource.apply(true)
+	}
+
+	if err != nil {
+		if errors.IsConflict(err) {
+			return fmt.Errorf("unable to apply resource due to conflicts with other field managers; "+
+				"resolve the conflicts or force ownership of the conflicting fields; %v", err)
+		}
+
+		return fmt.Errorf("unable to apply resource; %v", err)
+	}
+
+	return nil
+}
+
+// apply sends a server-side apply patch for the resource, optionally forcing ownership of any
+// conflicting fields.
+func (resource *Resource) apply(force bool) error {
+	// the apply configuration must not include the resource version, otherwise the patch is
+	// rejected when the resource has been modified, or the managed fields
+	object, ok := resource.Object.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unable to copy resource %s %s", resource.Kind, resource.Name)
+	}
+
+	object.SetResourceVersion("")
+	object.SetManagedFields(nil)
+
+	options := []client.PatchOption{client.FieldOwner(ApplyFieldManager)}
+	if force {
+		options = append(options, client.ForceOwnership)
+	}
+
+	return resource.Reconciler.Patch(resource.Reconciler.GetContext(), object, client.Apply, options...)
+}
+
+// conflictsOnlyWith returns whether each of the conflicts of a conflict error is with a given
+// field manager.
+func conflictsOnlyWith(err error, manager string) bool {
+	status, ok := err.(errors.APIStatus)
+	if !ok || status.Status().Details == nil || len(status.Status().Details.Causes) == 0 {
+		return false
+	}
+
+	for _, cause := range status.Status().Details.Causes {
+		if !strings.Contains(cause.Message, fmt.Sprintf("%q", manager)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NeedsApply determines if a resource needs to be applied.  A resource needs to be applied when
+// any of its desired fields differ from the actual resource, or when the apply field manager owns
+// fields of the actual resource which are no longer desired, so that they may be removed.  Fields
+// which are not owned by the apply field manager are not considered.
+func NeedsApply(desired, actual Resource) (bool, error) {
+	desiredResource, err := desired.ToUnstructured()
+	if err != nil {
+		return false, err
+	}
+
+	actualResource, err := actual.ToUnstructured()
+	if err != nil {
+		return false, err
+	}
+
+	owned, err := ownedFields(actualResource)
+	if err != nil {
+		return false, err
+	}
+
+	// the resource has not been applied, which is the case when it was persisted with a merge
+	// patch, so it must be applied to take ownership of the desired fields
+	if owned.Empty() {
+		return true, nil
+	}
+
+	desiredContent := appliedContent(desiredResource)
+	if !isSubset(desiredContent, actualResource.Object) {
+		return true, nil
+	}
+
+	needsApply := false
+
+	owned.Leaves().Iterate(func(path fieldpath.Path) {
+		if _, found := valueAtPath(desiredContent, path); !found {
+			needsApply = true
+		}
+	})
+
+	return needsApply, nil
+}
+
+// areOwnedFieldsEqual determines if the fields of two versions of a resource which are owned by
+// the apply field manager are equal.
+func areOwnedFieldsEqual(desired, actual Resource) (bool, error) {
+	desiredResource, err := desired.ToUnstructured()
+	if err != nil {
+		return false, err
+	}
+
+	actualResource, err := actual.ToUnstructured()
+	if err != nil {
+		return false, err
+	}
+
+	desiredOwned, err := ownedFields(desiredResource)
+	if err != nil {
+		return false, err
+	}
+
+	actualOwned, err := ownedFields(actualResource)
+	if err != nil {
+		return false, err
+	}
+
+	// resources which have not been applied are never equal, so that they are reconciled and
+	// applied
+	if actualOwned.Empty() || !desiredOwned.Equals(actualOwned) {
+		return false, nil
+	}
+
+	equal := true
+
+	actualOwned.Leaves().Iterate(func(path fieldpath.Path) {
+		desiredValue, desiredFound := valueAtPath(desiredResource.Object, path)
+		actualValue, actualFound := valueAtPath(actualResource.Object, path)
+
+		if desiredFound != actualFound || !reflect.DeepEqual(desiredValue, actualValue) {
+			equal = false
+		}
+	})
+
+	return equal, nil
+}
+
+// ownedFields returns the set of fields of a resource which are owned by the apply field manager.
+func ownedFields(resource *unstructured.Unstructured) (*fieldpath.Set, error) {
+	owned := &fieldpath.Set{}
+
+	for _, entry := range resource.GetManagedFields() {
+		if entry.Manager != ApplyFieldManager || entry.Operation != metav1.ManagedFieldsOperationApply {
+			continue
+		}
+
+		if entry.FieldsV1 == nil {
+			continue
+		}
+
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("unable to parse managed fields of resource %s %s; %w",
+				resource.GetKind(), resource.GetName(), err)
+		}
+
+		owned = owned.Union(fields)
+	}
+
+	return owned, nil
+}
+
+// appliedContent returns the content of a resource as it is sent in an apply patch.
+func appliedContent(resource *unstructured.Unstructured) map[string]interface{} {
+	content := resource.DeepCopy()
+	content.SetResourceVersion("")
+	content.SetManagedFields(nil)
+	content.SetCreationTimestamp(metav1.Time{})
+
+	unstructured.RemoveNestedField(content.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(content.Object, "status")
+
+	return content.Object
+}
+
+// isSubset returns whether each of the fields of a desired value are equal to the fields of an
+// actual value.  Each item of a desired list must be a subset of an item of the actual list, in
+// the same order, so that items which were added to the list by other field managers are ignored.
+func isSubset(desired, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return len(desiredValue) == 0 && actual == nil
+		}
+
+		for key, value := range desiredValue {
+			if !isSubset(value, actualValue[key]) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return len(desiredValue) == 0 && actual == nil
+		}
+
+		matched := 0
+
+		for i := 0; i < len(actualValue) && matched < len(desiredValue); i++ {
+			if isSubset(desiredValue[matched], actualValue[i]) {
+				matched++
+			}
+		}
+
+		return matched == len(desiredValue)
+	case nil:
+		return true
+	case string:
+		return desiredValue == actual || equalQuantities(desiredValue, actual)
+	default:
+		return reflect.DeepEqual(desired, actual)
+	}
+}
+
+// equalQuantities returns whether a desired string and an actual value are both quantities which
+// are equal, as quantities are stored in their canonical form (e.g. 1000m is stored as 1).
+func equalQuantities(desired string, actual interface{}) bool {
+	actualString, ok := actual.(string)
+	if !ok {
+		return false
+	}
+
+	desiredQuantity, err := resource.ParseQuantity(desired)
+	if err != nil {
+		return false
+	}
+
+	actualQuantity, err := resource.ParseQuantity(actualString)
+	if err != nil {
+		return false
+	}
+
+	return desiredQuantity.Cmp(actualQuantity) == 0
+}
+
+// valueAtPath returns the value of the field of an object at a path of a managed fields set.
+func valueAtPath(object interface{}, path fieldpath.Path) (interface{}, bool) {
+	current := object
+
+	for _, element := range path {
+		switch {
+		case element.FieldName != nil:
+			fields, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			value, found := fields[*element.FieldName]
+			if !found {
+				return nil, false
+			}
+
+			current = value
+		case element.Key != nil:
+			item, found := findListItem(current, func(item interface{}) bool {
+				fields, ok := item.(map[string]interface{})
+				if !ok {
+					return false
+				}
+
+				for _, key := range *element.Key {
+					if !reflect.DeepEqual(fields[key.Name], key.Value.Unstructured()) {
+						return false
+					}
+				}
+
+				return true
+			})
+			if !found {
+				return nil, false
+			}
+
+			current = item
+		case element.Value != nil:
+			item, found := findListItem(current, func(item interface{}) bool {
+				return reflect.DeepEqual(item, (*element.Value).Unstructured())
+			})
+			if !found {
+				return nil, false
+			}
+
+			current = item
+		case element.Index != nil:
+			items, ok := current.([]interface{})
+			if !ok || *element.Index >= len(items) {
+				return nil, false
+			}
+
+			current = items[*element.Index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// findListItem returns the first item of a list which matches.
+func findListItem(list interface{}, matches func(interface{}) bool) (interface{}, bool) {
+	items, ok := list.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	for _, item := range items {
+		if matches(item) {
+			return item, true
+		}
+	}
+
+	return nil, false
+}
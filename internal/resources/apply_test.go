@@ -0,0 +1,358 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// managedFields returns a managed fields entry of a field manager for a set of fields.
+func managedFields(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:   manager,
+		Operation: operation,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+// testConfigMap returns a ConfigMap with a set of data and managed fields.
+func testConfigMap(data map[string]string, managedFields ...metav1.ManagedFieldsEntry) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "config",
+			Namespace:     "tenant",
+			ManagedFields: managedFields,
+		},
+		Data: data,
+	}
+}
+
+func TestNeedsApply(t *testing.T) {
+	t.Parallel()
+
+	appliedData := managedFields(ApplyFieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:key":{}}}`)
+
+	tests := []struct {
+		name    string
+		desired client.Object
+		actual  client.Object
+		want    bool
+	}{
+		{
+			name:    "not applied",
+			desired: testConfigMap(map[string]string{"key": "value"}),
+			actual: testConfigMap(map[string]string{"key": "value"},
+				managedFields(FieldManager, metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:key":{}}}`)),
+			want: true,
+		},
+		{
+			name:    "equal",
+			desired: testConfigMap(map[string]string{"key": "value"}),
+			actual:  testConfigMap(map[string]string{"key": "value"}, appliedData),
+			want:    false,
+		},
+		{
+			name:    "desired field differs",
+			desired: testConfigMap(map[string]string{"key": "changed"}),
+			actual:  testConfigMap(map[string]string{"key": "value"}, appliedData),
+			want:    true,
+		},
+		{
+			name:    "desired field added",
+			desired: testConfigMap(map[string]string{"key": "value", "other": "value"}),
+			actual:  testConfigMap(map[string]string{"key": "value"}, appliedData),
+			want:    true,
+		},
+		{
+			name:    "owned field no longer desired",
+			desired: testConfigMap(map[string]string{}),
+			actual:  testConfigMap(map[string]string{"key": "value"}, appliedData),
+			want:    true,
+		},
+		{
+			name:    "field owned by another field manager",
+			desired: testConfigMap(map[string]string{"key": "value"}),
+			actual: testConfigMap(map[string]string{"key": "value", "other": "value"},
+				appliedData,
+				managedFields("kubectl", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:other":{}}}`)),
+			want: false,
+		},
+		{
+			name: "quantities in canonical form",
+			desired: &corev1.ResourceQuota{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "tenant"},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("2000m")},
+				},
+			},
+			actual: &corev1.ResourceQuota{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "quota",
+					Namespace: "tenant",
+					ManagedFields: []metav1.ManagedFieldsEntry{
+						managedFields(ApplyFieldManager, metav1.ManagedFieldsOperationApply, `{"f:spec":{"f:hard":{"f:limits.cpu":{}}}}`),
+					},
+				},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("2")},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NeedsApply(*NewResourceFromClient(tt.desired), *NewResourceFromClient(tt.actual))
+			if err != nil {
+				t.Fatalf("NeedsApply() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NeedsApply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSubset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired interface{}
+		actual  interface{}
+		want    bool
+	}{
+		{
+			name:    "equal maps",
+			desired: map[string]interface{}{"key": "value"},
+			actual:  map[string]interface{}{"key": "value"},
+			want:    true,
+		},
+		{
+			name:    "additional actual fields",
+			desired: map[string]interface{}{"key": "value"},
+			actual:  map[string]interface{}{"key": "value", "other": "value"},
+			want:    true,
+		},
+		{
+			name:    "missing actual field",
+			desired: map[string]interface{}{"key": "value"},
+			actual:  map[string]interface{}{"other": "value"},
+			want:    false,
+		},
+		{
+			name:    "different value",
+			desired: map[string]interface{}{"key": "value"},
+			actual:  map[string]interface{}{"key": "other"},
+			want:    false,
+		},
+		{
+			name:    "empty map and missing value",
+			desired: map[string]interface{}{"key": map[string]interface{}{}},
+			actual:  map[string]interface{}{},
+			want:    true,
+		},
+		{
+			name:    "nil desired value",
+			desired: map[string]interface{}{"key": nil},
+			actual:  map[string]interface{}{"key": "value"},
+			want:    true,
+		},
+		{
+			name:    "list items in order with additional items",
+			desired: []interface{}{"a", "c"},
+			actual:  []interface{}{"a", "b", "c"},
+			want:    true,
+		},
+		{
+			name:    "list items out of order",
+			desired: []interface{}{"c", "a"},
+			actual:  []interface{}{"a", "b", "c"},
+			want:    false,
+		},
+		{
+			name: "list of maps",
+			desired: []interface{}{
+				map[string]interface{}{"name": "registry"},
+			},
+			actual: []interface{}{
+				map[string]interface{}{"name": "other"},
+				map[string]interface{}{"name": "registry", "uid": "1234"},
+			},
+			want: true,
+		},
+		{
+			name:    "empty list and missing value",
+			desired: []interface{}{},
+			actual:  nil,
+			want:    true,
+		},
+		{
+			name:    "equal quantities",
+			desired: map[string]interface{}{"cpu": "1000m"},
+			actual:  map[string]interface{}{"cpu": "1"},
+			want:    true,
+		},
+		{
+			name:    "numbers",
+			desired: int64(1),
+			actual:  int64(1),
+			want:    true,
+		},
+		{
+			name:    "different types",
+			desired: int64(1),
+			actual:  "1",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isSubset(tt.desired, tt.actual); got != tt.want {
+				t.Errorf("isSubset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualQuantities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired string
+		actual  interface{}
+		want    bool
+	}{
+		{name: "canonical cpu", desired: "1000m", actual: "1", want: true},
+		{name: "canonical memory", desired: "1024Mi", actual: "1Gi", want: true},
+		{name: "different quantities", desired: "500m", actual: "1", want: false},
+		{name: "invalid desired quantity", desired: "one", actual: "1", want: false},
+		{name: "invalid actual quantity", desired: "1", actual: "one", want: false},
+		{name: "actual is not a string", desired: "1", actual: int64(1), want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := equalQuantities(tt.desired, tt.actual); got != tt.want {
+				t.Errorf("equalQuantities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueAtPath(t *testing.T) {
+	t.Parallel()
+
+	object := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":       "config",
+			"finalizers": []interface{}{"first", "second"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "app:1.0"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:1.0"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		path      fieldpath.Path
+		want      interface{}
+		wantFound bool
+	}{
+		{
+			name:      "field",
+			path:      fieldpath.MakePathOrDie("metadata", "name"),
+			want:      "config",
+			wantFound: true,
+		},
+		{
+			name:      "missing field",
+			path:      fieldpath.MakePathOrDie("metadata", "namespace"),
+			wantFound: false,
+		},
+		{
+			name:      "field of a value which is not a map",
+			path:      fieldpath.MakePathOrDie("metadata", "name", "first"),
+			wantFound: false,
+		},
+		{
+			name: "key",
+			path: fieldpath.MakePathOrDie("spec", "containers",
+				fieldpath.KeyByFields("name", "sidecar"), "image"),
+			want:      "sidecar:1.0",
+			wantFound: true,
+		},
+		{
+			name: "missing key",
+			path: fieldpath.MakePathOrDie("spec", "containers",
+				fieldpath.KeyByFields("name", "proxy"), "image"),
+			wantFound: false,
+		},
+		{
+			name:      "value",
+			path:      fieldpath.MakePathOrDie("metadata", "finalizers", value.NewValueInterface("second")),
+			want:      "second",
+			wantFound: true,
+		},
+		{
+			name:      "missing value",
+			path:      fieldpath.MakePathOrDie("metadata", "finalizers", value.NewValueInterface("third")),
+			wantFound: false,
+		},
+		{
+			name:      "index",
+			path:      fieldpath.MakePathOrDie("metadata", "finalizers", 1),
+			want:      "second",
+			wantFound: true,
+		},
+		{
+			name:      "index out of range",
+			path:      fieldpath.MakePathOrDie("metadata", "finalizers", 2),
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := valueAtPath(object, tt.path)
+			if found != tt.wantFound {
+				t.Fatalf("valueAtPath() found = %v, want %v", found, tt.wantFound)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueAtPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
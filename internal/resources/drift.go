@@ -0,0 +1,163 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+)
+
+// maxDriftFields is the maximum number of fields which are listed in the summary of a drift.
+const maxDriftFields = 5
+
+// Drift describes how a resource, which was previously created, differs from its desired state
+// because it was modified or deleted by something other than the controller.
+type Drift struct {
+	// Deleted is whether the resource was deleted.
+	Deleted bool
+
+	// Manager is the field manager which most recently modified the resource.
+	Manager string
+
+	// Fields are the paths of the desired fields which differ from the resource.
+	Fields []string
+}
+
+// String returns a summary of the drift.
+func (drift *Drift) String() string {
+	if drift.Deleted {
+		return "resource was deleted"
+	}
+
+	fields := drift.Fields
+	if len(fields) > maxDriftFields {
+		fields = append(fields[:maxDriftFields:maxDriftFields], fmt.Sprintf("and %d more", len(drift.Fields)-maxDriftFields))
+	}
+
+	return fmt.Sprintf("resource was modified by %s; fields: [%s]", drift.Manager, strings.Join(fields, ", "))
+}
+
+// DetectDrift returns the drift of a resource from its desired state, or nil if the resource has
+// not drifted.  Only resources which have previously been created, as recorded in the status of
+// the component, may drift.  A resource has drifted if it has been deleted, or if any of its
+// desired fields differ from the resource and the resource was most recently modified by a field
+// manager other than the controller, so that changes to the desired state are not reported as
+// drift.
+func DetectDrift(resource common.ComponentResource, previous *common.Resource) (*Drift, error) {
+	if previous == nil || !previous.Created {
+		return nil, nil
+	}
+
+	desired := resource.ToCommonResource()
+
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(resource.GetObject().GetObjectKind().GroupVersionKind())
+
+	if err := resource.GetReconciler().Get(
+		resource.GetReconciler().GetContext(),
+		client.ObjectKeyFromObject(resource.GetObject()),
+		actual,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return &Drift{Deleted: true}, nil
+		}
+
+		return nil, fmt.Errorf("unable to get resource %s %s; %w", desired.Kind, desired.Name, err)
+	}
+
+	manager := driftedBy(actual)
+	if manager == "" {
+		return nil, nil
+	}
+
+	desiredResource, err := NewResourceFromClient(resource.GetObject()).ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := diffPaths(appliedContent(desiredResource), actual.Object, "")
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return &Drift{Manager: manager, Fields: fields}, nil
+}
+
+// diffPaths returns the paths of the fields of a desired value which differ from an actual value.
+func diffPaths(desired, actual interface{}, path string) []string {
+	if isSubset(desired, actual) {
+		return nil
+	}
+
+	desiredFields, ok := desired.(map[string]interface{})
+	if !ok {
+		return []string{path}
+	}
+
+	actualFields, _ := actual.(map[string]interface{})
+
+	keys := make([]string, 0, len(desiredFields))
+	for key := range desiredFields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var paths []string
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+
+		paths = append(paths, diffPaths(desiredFields[key], actualFields[key], childPath)...)
+	}
+
+	return paths
+}
+
+// driftedBy returns the field manager, other than the field managers of the reconciler, which most
+// recently modified an existing resource after the reconciler last modified it.  An empty string
+// is returned if the resource has not drifted.
+func driftedBy(existing client.Object) string {
+	var (
+		lastApplied  time.Time
+		lastModified time.Time
+		manager      string
+	)
+
+	for _, entry := range existing.GetManagedFields() {
+		if entry.Time == nil {
+			continue
+		}
+
+		if entry.Manager == FieldManager || entry.Manager == ApplyFieldManager {
+			if entry.Time.After(lastApplied) {
+				lastApplied = entry.Time.Time
+			}
+
+			continue
+		}
+
+		if entry.Time.After(lastModified) {
+			lastModified = entry.Time.Time
+			manager = entry.Manager
+		}
+	}
+
+	if lastApplied.IsZero() || !lastModified.After(lastApplied) {
+		return ""
+	}
+
+	return manager
+}
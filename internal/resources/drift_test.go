@@ -0,0 +1,205 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired interface{}
+		actual  interface{}
+		want    []string
+	}{
+		{
+			name:    "no differences",
+			desired: map[string]interface{}{"data": map[string]interface{}{"key": "value"}},
+			actual:  map[string]interface{}{"data": map[string]interface{}{"key": "value", "other": "value"}},
+			want:    nil,
+		},
+		{
+			name: "nested differences in sorted order",
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"team": "platform"},
+				},
+				"data": map[string]interface{}{"b": "value", "a": "value", "c": "value"},
+			},
+			actual: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"team": "data"},
+				},
+				"data": map[string]interface{}{"a": "changed", "c": "value"},
+			},
+			want: []string{"data.a", "data.b", "metadata.labels.team"},
+		},
+		{
+			name:    "missing map",
+			desired: map[string]interface{}{"spec": map[string]interface{}{"hard": map[string]interface{}{"pods": "10"}}},
+			actual:  map[string]interface{}{},
+			want:    []string{"spec.hard.pods"},
+		},
+		{
+			name: "list",
+			desired: map[string]interface{}{
+				"subjects": []interface{}{map[string]interface{}{"name": "tenant-admins"}},
+			},
+			actual: map[string]interface{}{
+				"subjects": []interface{}{map[string]interface{}{"name": "other"}},
+			},
+			want: []string{"subjects"},
+		},
+		{
+			name:    "equal quantities",
+			desired: map[string]interface{}{"spec": map[string]interface{}{"hard": map[string]interface{}{"limits.cpu": "2000m"}}},
+			actual:  map[string]interface{}{"spec": map[string]interface{}{"hard": map[string]interface{}{"limits.cpu": "2"}}},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := diffPaths(tt.desired, tt.actual, ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftedBy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	entry := func(manager string, age time.Duration) metav1.ManagedFieldsEntry {
+		modified := metav1.NewTime(now.Add(-age))
+
+		return metav1.ManagedFieldsEntry{Manager: manager, Time: &modified}
+	}
+
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		want          string
+	}{
+		{
+			name:          "no managed fields",
+			managedFields: nil,
+			want:          "",
+		},
+		{
+			name:          "only modified by the reconciler",
+			managedFields: []metav1.ManagedFieldsEntry{entry(FieldManager, time.Minute)},
+			want:          "",
+		},
+		{
+			name: "modified before the reconciler",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", 2*time.Minute),
+				entry(FieldManager, time.Minute),
+			},
+			want: "",
+		},
+		{
+			name: "modified after the reconciler",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry(FieldManager, 2*time.Minute),
+				entry("kubectl", time.Minute),
+			},
+			want: "kubectl",
+		},
+		{
+			name: "modified after the apply field manager",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry(FieldManager, 3*time.Minute),
+				entry(ApplyFieldManager, 2*time.Minute),
+				entry("kubectl", time.Minute),
+			},
+			want: "kubectl",
+		},
+		{
+			name: "most recent field manager",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry(FieldManager, 3*time.Minute),
+				entry("helm", time.Minute),
+				entry("kubectl", 2*time.Minute),
+			},
+			want: "helm",
+		},
+		{
+			name: "never modified by the reconciler",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", time.Minute),
+			},
+			want: "",
+		},
+		{
+			name: "entries without a time",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry(FieldManager, time.Minute),
+				{Manager: "kubectl"},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := driftedBy(testConfigMap(nil, tt.managedFields...)); got != tt.want {
+				t.Errorf("driftedBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrift_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		drift *Drift
+		want  string
+	}{
+		{
+			name:  "deleted",
+			drift: &Drift{Deleted: true},
+			want:  "resource was deleted",
+		},
+		{
+			name:  "modified",
+			drift: &Drift{Manager: "kubectl", Fields: []string{"data.a", "data.b"}},
+			want:  "resource was modified by kubectl; fields: [data.a, data.b]",
+		},
+		{
+			name:  "modified with more fields than are listed",
+			drift: &Drift{Manager: "kubectl", Fields: []string{"a", "b", "c", "d", "e", "f", "g"}},
+			want:  "resource was modified by kubectl; fields: [a, b, c, d, e, and 2 more]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.drift.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
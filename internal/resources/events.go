@@ -0,0 +1,21 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+)
+
+// RecordEvent records an event against the component of a reconciler.  Events are not recorded if
+// the reconciler has no event recorder.
+func RecordEvent(r common.ComponentReconciler, eventType, reason, message string) {
+	component, ok := r.GetComponent().(runtime.Object)
+	if !ok || r.GetEventRecorder() == nil {
+		return
+	}
+
+	r.GetEventRecorder().Event(component, eventType, reason, message)
+}
@@ -4,12 +4,15 @@
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/banzaicloud/k8s-objectmatcher/patch"
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
 	"github.com/imdario/mergo"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -18,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
 )
 
 const (
@@ -29,7 +33,11 @@ func (resource *Resource) Create() error {
 	resource.Reconciler.GetLogger().V(0).Info(fmt.Sprintf("creating resource; kind: [%s], name: [%s], namespace: [%s]",
 		resource.Kind, resource.Name, resource.Namespace))
 
-	if err := resource.Reconciler.Create(
+	if ServerSideApply {
+		if err := resource.Apply(); err != nil {
+			return err
+		}
+	} else if err := resource.Reconciler.Create(
 		resource.Reconciler.GetContext(),
 		resource.Object,
 		&client.CreateOptions{FieldManager: FieldManager},
@@ -37,33 +45,155 @@ func (resource *Resource) Create() error {
 		return fmt.Errorf("unable to create resource; %v", err)
 	}
 
+	metrics.CountResourceOperation(resource.Kind, metrics.ResourceOperationCreate)
+	RecordEvent(resource.Reconciler, corev1.EventTypeNormal, "ResourceCreated",
+		fmt.Sprintf("created resource %s %s", resource.Kind, resource.namespacedName()))
+
 	return nil
 }
 
 // Update updates a resource.
 func (resource *Resource) Update(oldResource *Resource) error {
-	needsUpdate, err := NeedsUpdate(*resource, *oldResource)
+	var needsUpdate bool
+	var err error
+
+	if ServerSideApply {
+		needsUpdate, err = NeedsApply(*resource, *oldResource)
+	} else {
+		needsUpdate, err = NeedsUpdate(*resource, *oldResource)
+	}
+
 	if err != nil {
 		return err
 	}
 
 	if needsUpdate {
-		resource.Reconciler.GetLogger().V(0).Info(fmt.Sprintf("updating resource; kind: [%s], name: [%s], namespace: [%s]",
-			resource.Kind, resource.Name, resource.Namespace))
-
-		if err := resource.Reconciler.Patch(
-			resource.Reconciler.GetContext(),
-			resource.Object,
-			client.Merge,
-			&client.PatchOptions{FieldManager: FieldManager},
-		); err != nil {
-			return fmt.Errorf("unable to update resource; %v", err)
+		if ServerSideApply {
+			if err := resource.Apply(); err != nil {
+				return err
+			}
+		} else {
+			resource.Reconciler.GetLogger().V(0).Info(fmt.Sprintf("updating resource; kind: [%s], name: [%s], namespace: [%s]",
+				resource.Kind, resource.Name, resource.Namespace))
+
+			patch, err := mergePatch(resource, oldResource)
+			if err != nil {
+				return err
+			}
+
+			if err := resource.Reconciler.Patch(
+				resource.Reconciler.GetContext(),
+				resource.Object,
+				patch,
+				&client.PatchOptions{FieldManager: FieldManager},
+			); err != nil {
+				return fmt.Errorf("unable to update resource; %v", err)
+			}
 		}
+
+		metrics.CountResourceOperation(resource.Kind, metrics.ResourceOperationUpdate)
+		RecordEvent(resource.Reconciler, corev1.EventTypeNormal, "ResourceUpdated",
+			fmt.Sprintf("updated resource %s %s", resource.Kind, resource.namespacedName()))
 	}
 
 	return nil
 }
 
+// mergePatch returns the merge patch which updates a resource to its desired state.  Labels and
+// annotations which were previously managed, as recorded in the managed labels and annotations
+// annotations of the resource, but which are no longer desired are removed by the patch.
+func mergePatch(desired, actual *Resource) (client.Patch, error) {
+	desiredResource, err := desired.ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+
+	content := desiredResource.DeepCopy().Object
+
+	for field, managedAnnotation := range map[string]string{
+		"labels":      common.ManagedLabelsAnnotation,
+		"annotations": common.ManagedAnnotationsAnnotation,
+	} {
+		previous := managedKeys(actual.Object.GetAnnotations()[managedAnnotation])
+		current := managedKeys(desiredResource.GetAnnotations()[managedAnnotation])
+
+		for key := range previous {
+			if current[key] {
+				continue
+			}
+
+			setNull(content, "metadata", field, key)
+		}
+
+		if len(previous) > 0 && len(current) == 0 {
+			setNull(content, "metadata", "annotations", managedAnnotation)
+		}
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create patch for resource %s %s; %w", desired.Kind, desired.Name, err)
+	}
+
+	return client.RawPatch(types.MergePatchType, data), nil
+}
+
+// hasStaleMetadata returns whether a resource has labels or annotations which were previously
+// managed but which are no longer desired.
+func hasStaleMetadata(desired, actual Resource) bool {
+	for _, managedAnnotation := range []string{common.ManagedLabelsAnnotation, common.ManagedAnnotationsAnnotation} {
+		current := managedKeys(desired.Object.GetAnnotations()[managedAnnotation])
+
+		for key := range managedKeys(actual.Object.GetAnnotations()[managedAnnotation]) {
+			if !current[key] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// managedKeys returns the keys of a managed labels or annotations annotation.
+func managedKeys(value string) map[string]bool {
+	keys := map[string]bool{}
+
+	for _, key := range strings.Split(value, ",") {
+		if key != "" {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
+
+// setNull sets a field of an object to null, which removes the field when the object is sent as
+// a merge patch.
+func setNull(object map[string]interface{}, fields ...string) {
+	current := object
+
+	for _, field := range fields[:len(fields)-1] {
+		next, ok := current[field].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[field] = next
+		}
+
+		current = next
+	}
+
+	current[fields[len(fields)-1]] = nil
+}
+
+// namespacedName returns the name of a resource, prefixed with its namespace if it is namespaced.
+func (resource *Resource) namespacedName() string {
+	if resource.Namespace == "" {
+		return resource.Name
+	}
+
+	return resource.Namespace + "/" + resource.Name
+}
+
 // NewResourceFromClient returns a new resource given a client object.  It optionally will take in
 // a reconciler and set it.
 func NewResourceFromClient(resource client.Object, reconciler ...common.ComponentReconciler) *Resource {
@@ -150,8 +280,13 @@ func AreReady(resources ...common.ComponentResource) (bool, error) {
 	return true, nil
 }
 
-// AreEqual determines if two resources are equal.
+// AreEqual determines if two resources are equal.  When resources are persisted with server-side
+// apply, only the fields which are owned by the apply field manager are compared.
 func AreEqual(desired, actual Resource) (bool, error) {
+	if ServerSideApply {
+		return areOwnedFieldsEqual(desired, actual)
+	}
+
 	mergedResource, err := actual.ToUnstructured()
 	if err != nil {
 		return false, err
@@ -208,6 +343,12 @@ func AreEqual(desired, actual Resource) (bool, error) {
 
 // NeedsUpdate determines if a resource needs to be updated.
 func NeedsUpdate(desired, actual Resource) (bool, error) {
+	// labels and annotations which are no longer desired are not detected by comparing the
+	// resources, as they are only present on the actual resource
+	if hasStaleMetadata(desired, actual) {
+		return true, nil
+	}
+
 	// check for equality first as this will let us avoid spamming user logs
 	// when resources that need to be skipped explicitly (e.g. CRDs) are seen
 	// as equal anyway
@@ -254,6 +395,11 @@ func (resource *Resource) GetObject() client.Object {
 	return resource.Object
 }
 
+// IsShared returns the Shared field of a Resource.
+func (resource *Resource) IsShared() bool {
+	return resource.Shared
+}
+
 // GetReconciler returns the Reconciler field of a Resource.
 func (resource *Resource) GetReconciler() common.ComponentReconciler {
 	return resource.Reconciler
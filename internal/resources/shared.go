@@ -0,0 +1,84 @@
+// Copyright 2006-2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/metrics"
+)
+
+// PatchShared patches a shared resource, which exists independently of the component, with the
+// desired fields of the resource.  Fields which are not desired are left unchanged.
+func (resource *Resource) PatchShared(oldResource *Resource) error {
+	desired, err := resource.ToUnstructured()
+	if err != nil {
+		return err
+	}
+
+	actual, err := oldResource.ToUnstructured()
+	if err != nil {
+		return err
+	}
+
+	patched := sharedContent(desired, actual)
+	if equality.Semantic.DeepEqual(actual.Object, patched.Object) {
+		return nil
+	}
+
+	resource.Reconciler.GetLogger().V(0).Info(fmt.Sprintf("patching shared resource; kind: [%s], name: [%s], namespace: [%s]",
+		resource.Kind, resource.Name, resource.Namespace))
+
+	if err := resource.Reconciler.Patch(
+		resource.Reconciler.GetContext(),
+		patched,
+		client.MergeFrom(actual),
+		&client.PatchOptions{FieldManager: FieldManager},
+	); err != nil {
+		return fmt.Errorf("unable to patch shared resource; %v", err)
+	}
+
+	metrics.CountResourceOperation(resource.Kind, metrics.ResourceOperationUpdate)
+	RecordEvent(resource.Reconciler, corev1.EventTypeNormal, "ResourceUpdated",
+		fmt.Sprintf("updated shared resource %s %s", resource.Kind, resource.namespacedName()))
+
+	return nil
+}
+
+// sharedContent returns the content of a shared resource once the desired fields and annotations
+// have been applied to its actual content.
+func sharedContent(desired, actual *unstructured.Unstructured) *unstructured.Unstructured {
+	patched := actual.DeepCopy()
+
+	for field, value := range desired.Object {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+
+		patched.Object[field] = value
+	}
+
+	if len(desired.GetAnnotations()) == 0 {
+		return patched
+	}
+
+	annotations := patched.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	for key, value := range desired.GetAnnotations() {
+		annotations[key] = value
+	}
+
+	patched.SetAnnotations(annotations)
+
+	return patched
+}
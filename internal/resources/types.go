@@ -9,10 +9,20 @@ import (
 	"github.com/vmware-tanzu-labs/namespace-operator/apis/common"
 )
 
+// TanzuNamespaceLabel is the label which is set on each child resource to identify the
+// TanzuNamespace which manages it.
+const TanzuNamespaceLabel = "tenancy.platform.cnr.vmware.com/tanzunamespace"
+
 // Resource represents a resource as managed during the reconciliation process.
 type Resource struct {
 	common.ResourceCommon
 
 	Object     client.Object
 	Reconciler common.ComponentReconciler
+
+	// Shared is set for resources which exist independently of the component, such as the default
+	// ServiceAccount of a namespace.  Shared resources are patched without a controller reference
+	// and are never recorded in the status of the component, so that they are never pruned or
+	// deleted along with the component.
+	Shared bool
 }
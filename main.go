@@ -4,23 +4,34 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	tenancyv1alpha1 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha1"
 	tenancyv1alpha2 "github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2"
+	"github.com/vmware-tanzu-labs/namespace-operator/apis/tenancy/v1alpha2/tanzunamespace"
 	tenancycontrollers "github.com/vmware-tanzu-labs/namespace-operator/controllers/tenancy"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/mutate"
+	"github.com/vmware-tanzu-labs/namespace-operator/internal/resources"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -37,10 +48,40 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(tenancyv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(tenancyv1alpha2.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
+// checkAllowedClusterRoles returns an error if the operator is not permitted to bind each of the
+// allowed ClusterRoles, so that the --allowed-cluster-roles flag always agrees with the
+// resourceNames of the clusterroles bind rule of the operator.
+func checkAllowedClusterRoles(ctx context.Context, c client.Client) error {
+	for _, clusterRole := range tenancyv1alpha2.AllowedClusterRoles {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:    rbacv1.GroupName,
+					Resource: "clusterroles",
+					Verb:     "bind",
+					Name:     clusterRole,
+				},
+			},
+		}
+
+		if err := c.Create(ctx, review); err != nil {
+			return fmt.Errorf("unable to review access to bind cluster role %s; %w", clusterRole, err)
+		}
+
+		if !review.Status.Allowed {
+			return fmt.Errorf("operator is not permitted to bind cluster role %s; "+
+				"add it to the resourceNames of the clusterroles bind rule of the operator", clusterRole)
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	var metricsAddr string
 
@@ -48,11 +89,44 @@ func main() {
 
 	var probeAddr string
 
+	var maxConcurrentReconciles int
+
+	var imagePullSecretNamespace string
+
+	var serverSideApply bool
+
+	var forceApplyConflicts bool
+
+	var defaultPodSecurity tenancyv1alpha2.TanzuNamespaceSpecPodSecurity
+
+	var defaultPodSecurityVersion string
+
+	var allowedClusterRoles string
+
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The maximum number of TanzuNamespace objects which may be reconciled concurrently.")
+	flag.StringVar(&imagePullSecretNamespace, "image-pull-secret-namespace", mutate.ImagePullSecretSourceNamespace,
+		"The namespace which stores the source secrets that image pull secrets are copied from.")
+	flag.BoolVar(&serverSideApply, "server-side-apply", resources.ServerSideApply,
+		"Persist child resources with server-side apply rather than with a merge patch.")
+	flag.BoolVar(&forceApplyConflicts, "server-side-apply-force-conflicts", resources.ForceApplyConflicts,
+		"Take ownership of fields of child resources which conflict with other field managers when using server-side apply.")
+	flag.StringVar((*string)(&defaultPodSecurity.Enforce), "default-pod-security-enforce", "",
+		"The Pod Security Standards level which is enforced in namespaces which do not specify podSecurity.")
+	flag.StringVar((*string)(&defaultPodSecurity.Audit), "default-pod-security-audit", "",
+		"The Pod Security Standards level which is audited in namespaces which do not specify podSecurity.")
+	flag.StringVar((*string)(&defaultPodSecurity.Warn), "default-pod-security-warn", "",
+		"The Pod Security Standards level which is warned about in namespaces which do not specify podSecurity.")
+	flag.StringVar(&defaultPodSecurityVersion, "default-pod-security-version", "",
+		"The version of the default Pod Security Standards, e.g. latest or v1.25.")
+	flag.StringVar(&allowedClusterRoles, "allowed-cluster-roles", strings.Join(tenancyv1alpha2.AllowedClusterRoles, ","),
+		"The comma-separated names of the ClusterRoles which bindings may reference.  The operator must also be "+
+			"permitted to bind each of them.")
 
 	opts := zap.Options{
 		Development: true,
@@ -62,6 +136,40 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	mutate.ImagePullSecretSourceNamespace = imagePullSecretNamespace
+	resources.ServerSideApply = serverSideApply
+	resources.ForceApplyConflicts = forceApplyConflicts
+	tenancyv1alpha2.AllowedClusterRoles = nil
+
+	for _, clusterRole := range strings.Split(allowedClusterRoles, ",") {
+		if clusterRole = strings.TrimSpace(clusterRole); clusterRole != "" {
+			tenancyv1alpha2.AllowedClusterRoles = append(tenancyv1alpha2.AllowedClusterRoles, clusterRole)
+		}
+	}
+
+	if defaultPodSecurity != (tenancyv1alpha2.TanzuNamespaceSpecPodSecurity{}) {
+		if defaultPodSecurityVersion != "" {
+			if defaultPodSecurity.Enforce != "" {
+				defaultPodSecurity.EnforceVersion = defaultPodSecurityVersion
+			}
+
+			if defaultPodSecurity.Audit != "" {
+				defaultPodSecurity.AuditVersion = defaultPodSecurityVersion
+			}
+
+			if defaultPodSecurity.Warn != "" {
+				defaultPodSecurity.WarnVersion = defaultPodSecurityVersion
+			}
+		}
+
+		if errs := defaultPodSecurity.Validate(field.NewPath("default-pod-security")); len(errs) > 0 {
+			setupLog.Error(errs.ToAggregate(), "invalid default pod security standards")
+			os.Exit(1)
+		}
+
+		tanzunamespace.DefaultPodSecurity = &defaultPodSecurity
+	}
+
 	// only print a given warning the first time we receive it
 	rest.SetDefaultWarningHandler(
 		rest.NewWarningWriter(os.Stderr, rest.WarningWriterOptions{
@@ -82,12 +190,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := checkAllowedClusterRoles(context.Background(), mgr.GetClient()); err != nil {
+		setupLog.Error(err, "invalid allowed cluster roles")
+		os.Exit(1)
+	}
+
 	reconcilers := []ReconcilerInitializer{
 		&tenancycontrollers.TanzuNamespaceReconciler{
-			Name:   "TanzuNamespace",
+			Name:      "TanzuNamespace",
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("tenancy").WithName("TanzuNamespace"),
+			Scheme:    mgr.GetScheme(),
+			Recorder:  mgr.GetEventRecorderFor("tanzunamespace-controller"),
+
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		},
+		&tenancycontrollers.TanzuNamespaceQuotaStatusReconciler{
+			Name:   "TanzuNamespaceQuotaStatus",
 			Client: mgr.GetClient(),
-			Log:    ctrl.Log.WithName("controllers").WithName("tenancy").WithName("TanzuNamespace"),
-			Scheme: mgr.GetScheme(),
+			Log:    ctrl.Log.WithName("controllers").WithName("tenancy").WithName("TanzuNamespaceQuotaStatus"),
 		},
 		//+kubebuilder:scaffold:reconcilers
 	}
@@ -99,6 +221,16 @@ func main() {
 		}
 	}
 
+	// webhooks, including the conversion webhook between the v1alpha1 and v1alpha2 versions, may be
+	// disabled when running the manager locally without certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&tenancyv1alpha2.TanzuNamespace{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TanzuNamespace")
+			os.Exit(1)
+		}
+	}
+	//+kubebuilder:scaffold:webhook
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)